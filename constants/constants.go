@@ -10,8 +10,15 @@ import (
 const PoolInitCodeHash = "0xe34f199b19b2b4f47f68442619d555527d244f78a3297ea89325f843f87b8b54"
 
 var (
-	FactoryAddress = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
-	AddressZero    = common.HexToAddress("0x0000000000000000000000000000000000000000")
+	FactoryAddress                    = common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984")
+	SwapRouterAddress                 = common.HexToAddress("0xE592427A0AEce92De3Edee1F18E0157C05861564")
+	SwapRouter02Address               = common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45")
+	NonfungiblePositionManagerAddress = common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88")
+	V3MigratorAddress                 = common.HexToAddress("0xA5644E29708357803b5A882D272c41cC0dF92B34")
+	QuoterV2Address                   = common.HexToAddress("0x61fFE014bA17989E743c5F6cB21bF9697530B21e")
+	QuoterAddress                     = common.HexToAddress("0xb27308f9F90D607463bb33eA1BeBb41C27CE5AB6")
+	UniswapInterfaceMulticallAddress  = common.HexToAddress("0x1F98415757620B543A52E61c46B32eB19261F984")
+	AddressZero                       = common.HexToAddress("0x0000000000000000000000000000000000000000")
 )
 
 // The default factory enabled fee amounts, denominated in hundredths of bips.
@@ -43,5 +50,8 @@ var (
 	Q96  = new(big.Int).Exp(big.NewInt(2), big.NewInt(96), nil)
 	Q192 = new(big.Int).Exp(Q96, big.NewInt(2), nil)
 
+	// used in fee growth math
+	Q128 = new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil)
+
 	PercentZero = entities.NewFraction(big.NewInt(0), big.NewInt(1))
 )
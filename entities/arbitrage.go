@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+// the largest input amount tried while bracketing the most profitable input of a cycle
+var maxArbitrageAmountIn = new(big.Int).Lsh(constants.One, 128)
+
+/**
+ * Profit comparator, orders trades that start and end in the same token by descending profit
+ * @param a The first trade to compare
+ * @param b The second trade to compare
+ * @returns A sorted ordering for two neighboring elements in a trade array
+ */
+func profitComparator(a, b *Trade) int {
+	return tradeProfit(b).Cmp(tradeProfit(a))
+}
+
+// tradeProfit returns the raw amount of output left after paying back the input of a cyclic trade
+func tradeProfit(t *Trade) *big.Int {
+	return new(big.Int).Sub(t.OutputAmount().Quotient(), t.InputAmount().Quotient())
+}
+
+/**
+ * Given a list of pools, returns the top `maxNumResults` cyclic trades that start and end with the given token and
+ * return more of it than they take in, ranked by profit.
+ * Cycles are found by walking pools whose marginal price product, net of fees, is above 1. The input of each
+ * cycle is then sized by simulating swaps through the route to maximize the profit.
+ * @param pools the pools to consider in finding cycles
+ * @param token the token every cycle starts and ends with
+ * @param opts maximum number of results to return and maximum number of hops a cycle can make
+ * @returns The exact in trades, most profitable first
+ */
+func BestArbitrageTrades(pools []*Pool, token *entities.Token, opts *BestTradeOptions) ([]*Trade, error) {
+	if len(pools) <= 0 {
+		return nil, ErrNoPools
+	}
+	if opts == nil {
+		opts = &BestTradeOptions{MaxNumResults: 3, MaxHops: 3}
+	}
+	if opts.MaxHops <= 0 {
+		return nil, ErrInvalidMaxHops
+	}
+
+	var bestTrades []*Trade
+	for _, cycle := range findCycles(pools, token, opts.MaxHops, nil, token) {
+		if !isProfitableCycle(cycle, token) {
+			continue
+		}
+		route, err := NewRoute(cycle, token, token)
+		if err != nil {
+			return nil, err
+		}
+		amountIn := optimalCycleAmountIn(route)
+		if amountIn == nil {
+			continue
+		}
+		trade, err := FromRoute(route, entities.FromRawAmount(token, amountIn), entities.ExactInput)
+		if err != nil {
+			return nil, err
+		}
+		bestTrades, err = sortedInsert(bestTrades, trade, opts.MaxNumResults, profitComparator)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bestTrades, nil
+}
+
+// findCycles returns every sequence of distinct pools, at most maxHops long, that leads from tokenIn back to start
+func findCycles(pools []*Pool, tokenIn *entities.Token, maxHops int, currentPools []*Pool, start *entities.Token) [][]*Pool {
+	var cycles [][]*Pool
+	for i, pool := range pools {
+		if !pool.InvolvesToken(tokenIn) {
+			continue
+		}
+		tokenOut := pool.Token0
+		if pool.Token0.Equal(tokenIn) {
+			tokenOut = pool.Token1
+		}
+		path := append(append([]*Pool{}, currentPools...), pool)
+		if tokenOut.Equal(start) {
+			if len(path) > 1 {
+				cycles = append(cycles, path)
+			}
+			continue
+		}
+		if maxHops > 1 {
+			var poolsExcludingThisPool []*Pool
+			poolsExcludingThisPool = append(poolsExcludingThisPool, pools[:i]...)
+			poolsExcludingThisPool = append(poolsExcludingThisPool, pools[i+1:]...)
+			cycles = append(cycles, findCycles(poolsExcludingThisPool, tokenOut, maxHops-1, path, start)...)
+		}
+	}
+	return cycles
+}
+
+// isProfitableCycle returns whether the product of the marginal prices along the cycle, after fees, is above 1
+func isProfitableCycle(cycle []*Pool, token *entities.Token) bool {
+	product := entities.NewFraction(constants.One, constants.One)
+	tokenIn := token
+	for _, pool := range cycle {
+		price, err := pool.PriceOf(tokenIn)
+		if err != nil {
+			return false
+		}
+		feeFactor := entities.NewFraction(new(big.Int).Sub(utils.MaxFee, big.NewInt(int64(pool.Fee))), utils.MaxFee)
+		product = product.Multiply(price.Fraction).Multiply(feeFactor)
+		if pool.Token0.Equal(tokenIn) {
+			tokenIn = pool.Token1
+		} else {
+			tokenIn = pool.Token0
+		}
+	}
+	return product.GreaterThan(entities.NewFraction(constants.One, constants.One))
+}
+
+// cycleProfit simulates swapping amountIn through the route, returning nil if the swap cannot be simulated
+func cycleProfit(route *Route, amountIn *big.Int) *big.Int {
+	amount := entities.FromRawAmount(route.Input.Wrapped(), amountIn)
+	for _, pool := range route.Pools {
+		outputAmount, _, err := pool.GetOutputAmount(amount, nil)
+		if err != nil {
+			return nil
+		}
+		amount = outputAmount
+	}
+	return new(big.Int).Sub(amount.Quotient(), amountIn)
+}
+
+// greaterProfit returns whether profit a is strictly greater than profit b, treating nil as the lowest profit
+func greaterProfit(a, b *big.Int) bool {
+	if a == nil {
+		return false
+	}
+	return b == nil || a.Cmp(b) > 0
+}
+
+/**
+ * Finds the input amount that maximizes the profit of swapping around a cycle. The profit is concave in the input,
+ * so the maximum is first bracketed by doubling the input, then narrowed down by ternary search.
+ * @param route the cyclic route
+ * @returns The most profitable input amount, or nil if no input is profitable
+ */
+func optimalCycleAmountIn(route *Route) *big.Int {
+	var (
+		bestAmount *big.Int
+		bestProfit *big.Int
+		lo, hi     *big.Int
+	)
+	for amount := big.NewInt(1); amount.Cmp(maxArbitrageAmountIn) <= 0; amount = new(big.Int).Lsh(amount, 1) {
+		profit := cycleProfit(route, amount)
+		if greaterProfit(profit, bestProfit) {
+			bestAmount, bestProfit = amount, profit
+			continue
+		}
+		if bestProfit != nil && bestProfit.Sign() > 0 {
+			lo, hi = new(big.Int).Rsh(bestAmount, 1), amount
+			break
+		}
+	}
+	if bestProfit == nil || bestProfit.Sign() <= 0 {
+		return nil
+	}
+	if lo == nil {
+		return bestAmount
+	}
+
+	three := big.NewInt(3)
+	for new(big.Int).Sub(hi, lo).Cmp(three) > 0 {
+		third := new(big.Int).Div(new(big.Int).Sub(hi, lo), three)
+		m1 := new(big.Int).Add(lo, third)
+		m2 := new(big.Int).Sub(hi, third)
+		if greaterProfit(cycleProfit(route, m2), cycleProfit(route, m1)) {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+	for amount := new(big.Int).Set(lo); amount.Cmp(hi) <= 0; amount = new(big.Int).Add(amount, constants.One) {
+		profit := cycleProfit(route, amount)
+		if greaterProfit(profit, bestProfit) {
+			bestAmount, bestProfit = amount, profit
+		}
+	}
+	return bestAmount
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBestArbitrageTrades(t *testing.T) {
+	_, err := BestArbitrageTrades(nil, token0, nil)
+	assert.ErrorIs(t, err, ErrNoPools, "throws with empty pools")
+
+	_, err = BestArbitrageTrades([]*Pool{pool_0_1}, token0, &BestTradeOptions{MaxNumResults: 3, MaxHops: 0})
+	assert.ErrorIs(t, err, ErrInvalidMaxHops, "throws with max hops of 0")
+
+	// finds nothing when prices are consistent
+	trades, err := BestArbitrageTrades([]*Pool{pool_0_1, pool_weth_0, pool_weth_1}, token0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, trades, 0)
+
+	// finds nothing when the cycle is too long
+	trades, err = BestArbitrageTrades([]*Pool{pool_0_1, pool_0_2, pool_1_2}, token0, &BestTradeOptions{MaxNumResults: 3, MaxHops: 2})
+	assert.NoError(t, err)
+	assert.Len(t, trades, 0)
+
+	// finds the profitable direction of a mispriced cycle
+	trades, err = BestArbitrageTrades([]*Pool{pool_0_1, pool_0_2, pool_1_2}, token0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, trades, 1)
+	trade := trades[0]
+	assert.Equal(t, []*Pool{pool_0_2, pool_1_2, pool_0_1}, trade.Swaps[0].Route.Pools)
+	assert.True(t, trade.InputAmount().Currency.Equal(token0))
+	assert.True(t, trade.OutputAmount().Currency.Equal(token0))
+	profit := tradeProfit(trade)
+	assert.True(t, profit.Sign() > 0)
+
+	// the input is sized to maximize profit
+	route := trade.Swaps[0].Route
+	amountIn := trade.InputAmount().Quotient()
+	for _, other := range []*big.Int{
+		new(big.Int).Div(amountIn, big.NewInt(2)),
+		new(big.Int).Sub(amountIn, big.NewInt(100)),
+		new(big.Int).Add(amountIn, big.NewInt(100)),
+		new(big.Int).Mul(amountIn, big.NewInt(2)),
+	} {
+		assert.True(t, cycleProfit(route, other).Cmp(profit) <= 0)
+	}
+
+	// ranks several cycles by profit
+	trades, err = BestArbitrageTrades([]*Pool{pool_0_1, pool_0_2, pool_1_2, pool_0_3, pool_1_3}, token0, nil)
+	assert.NoError(t, err)
+	assert.True(t, len(trades) > 1)
+	for i := 1; i < len(trades); i++ {
+		assert.True(t, tradeProfit(trades[i-1]).Cmp(tradeProfit(trades[i])) >= 0)
+	}
+	for _, trade := range trades {
+		assert.True(t, trade.OutputAmount().GreaterThan(trade.InputAmount().Fraction))
+		assert.True(t, trade.InputAmount().Currency.Equal(token0))
+	}
+}
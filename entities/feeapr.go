@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var (
+	ErrNoTickData       = errors.New("no tick data")
+	ErrZeroLiquidity    = errors.New("zero liquidity")
+	ErrInvalidFeePeriod = errors.New("invalid fee period")
+)
+
+const year = 365 * 24 * time.Hour
+
+// VolumeBucket is swap volume traded while the pool price was at a tick, e.g. one decoded Swap event
+type VolumeBucket struct {
+	Tick    int      // The tick of the pool price while the volume was traded
+	Volume0 *big.Int // The raw amount of token0 swapped in
+	Volume1 *big.Int // The raw amount of token1 swapped in
+}
+
+// FeeEstimate is the projected fees of a position over a period of volume
+type FeeEstimate struct {
+	Fees0         *entities.CurrencyAmount // The fees earned in token0
+	Fees1         *entities.CurrencyAmount // The fees earned in token1
+	FeesValue     *entities.CurrencyAmount // The value of the fees at the pool price
+	PositionValue *entities.CurrencyAmount // The value of the amounts needed to mint the position at the pool price
+	FeeShare      *entities.Percent        // The share of all fees paid by the volume that the position earns
+	APR           *entities.Percent        // The annualized return of the fees on the position value
+}
+
+/**
+ * Returns a volume bucket for a decoded Swap event, whose amounts are positive for the token swapped in
+ * @param amount0 The amount0 of the event
+ * @param amount1 The amount1 of the event
+ * @param tick The tick of the event, i.e. of the pool after the swap
+ */
+func VolumeBucketFromSwap(amount0, amount1 *big.Int, tick int) VolumeBucket {
+	bucket := VolumeBucket{Tick: tick, Volume0: constants.Zero, Volume1: constants.Zero}
+	if amount0.Sign() > 0 {
+		bucket.Volume0 = amount0
+	}
+	if amount1.Sign() > 0 {
+		bucket.Volume1 = amount1
+	}
+	return bucket
+}
+
+/**
+ * Returns the in range liquidity of the pool when its price is at a tick, from the current liquidity and the
+ * liquidity net of the ticks crossed on the way
+ * @param tick The tick to return the liquidity at
+ */
+func (p *Pool) LiquidityAtTick(tick int) (*big.Int, error) {
+	liquidity := p.Liquidity
+	if tick == p.TickCurrent {
+		return liquidity, nil
+	}
+	if p.TickDataProvider == nil {
+		return nil, ErrNoTickData
+	}
+	if tick > p.TickCurrent {
+		for current := p.TickCurrent; ; {
+			next, initialized := p.TickDataProvider.NextInitializedTickWithinOneWord(current, false, p.tickSpacing())
+			if next > tick || next >= utils.MaxTick {
+				break
+			}
+			if initialized {
+				liquidity = utils.AddDelta(liquidity, p.TickDataProvider.GetTick(next).LiquidityNet)
+			}
+			current = next
+		}
+	} else {
+		for current := p.TickCurrent; ; {
+			next, initialized := p.TickDataProvider.NextInitializedTickWithinOneWord(current, true, p.tickSpacing())
+			if next <= tick || next <= utils.MinTick {
+				break
+			}
+			if initialized {
+				liquidity = utils.AddDelta(liquidity, new(big.Int).Neg(p.TickDataProvider.GetTick(next).LiquidityNet))
+			}
+			current = next - 1
+		}
+	}
+	return liquidity, nil
+}
+
+/**
+ * Estimates the fees a position in a tick range would have earned from a history of volume, and the APR they imply.
+ * The position earns the pool fee on each bucket of volume traded within its range, in proportion to its share of the
+ * pool's in range liquidity at the bucket's tick, including the position's own liquidity.
+ * @param pool The pool, with the tick data to derive the liquidity at each tick
+ * @param tickLower The lower tick of the range
+ * @param tickUpper The upper tick of the range
+ * @param liquidity The liquidity of the position
+ * @param buckets The volume traded over the period
+ * @param period The length of time the volume was traded over
+ * @param quote The token to value the fees and the position in
+ * @returns The estimated fees and APR
+ */
+func EstimateFeeAPR(pool *Pool, tickLower, tickUpper int, liquidity *big.Int, buckets []VolumeBucket, period time.Duration, quote *entities.Token) (*FeeEstimate, error) {
+	position, err := NewPosition(pool, liquidity, tickLower, tickUpper)
+	if err != nil {
+		return nil, err
+	}
+	if liquidity.Sign() <= 0 {
+		return nil, ErrZeroLiquidity
+	}
+	if period <= 0 {
+		return nil, ErrInvalidFeePeriod
+	}
+	if !pool.InvolvesToken(quote) {
+		return nil, ErrTokenNotInvolved
+	}
+
+	// fees as fractions, to keep the share of each bucket exact
+	fees0, fees1 := entities.NewFraction(constants.Zero, constants.One), entities.NewFraction(constants.Zero, constants.One)
+	total0, total1 := new(big.Int), new(big.Int)
+	fee := big.NewInt(int64(pool.Fee))
+	for _, bucket := range buckets {
+		bucketFee0 := new(big.Int).Div(new(big.Int).Mul(bucket.Volume0, fee), utils.MaxFee)
+		bucketFee1 := new(big.Int).Div(new(big.Int).Mul(bucket.Volume1, fee), utils.MaxFee)
+		total0.Add(total0, bucketFee0)
+		total1.Add(total1, bucketFee1)
+		if bucket.Tick < tickLower || bucket.Tick >= tickUpper {
+			continue
+		}
+		active, err := pool.LiquidityAtTick(bucket.Tick)
+		if err != nil {
+			return nil, err
+		}
+		share := entities.NewFraction(liquidity, new(big.Int).Add(active, liquidity))
+		fees0 = fees0.Add(share.Multiply(entities.NewFraction(bucketFee0, constants.One)))
+		fees1 = fees1.Add(share.Multiply(entities.NewFraction(bucketFee1, constants.One)))
+	}
+
+	estimate := &FeeEstimate{
+		Fees0: entities.FromRawAmount(pool.Token0, fees0.Quotient()),
+		Fees1: entities.FromRawAmount(pool.Token1, fees1.Quotient()),
+	}
+	if estimate.FeesValue, err = pool.valueAtSqrtPrice(pool.SqrtRatioX96, estimate.Fees0.Quotient(), estimate.Fees1.Quotient(), quote); err != nil {
+		return nil, err
+	}
+	totalValue, err := pool.valueAtSqrtPrice(pool.SqrtRatioX96, total0, total1, quote)
+	if err != nil {
+		return nil, err
+	}
+	amount0, amount1, err := position.MintAmounts()
+	if err != nil {
+		return nil, err
+	}
+	if estimate.PositionValue, err = pool.valueAtSqrtPrice(pool.SqrtRatioX96, amount0, amount1, quote); err != nil {
+		return nil, err
+	}
+
+	estimate.FeeShare = entities.NewPercent(constants.Zero, constants.One)
+	if totalValue.Numerator.Sign() > 0 {
+		share := estimate.FeesValue.Divide(totalValue.Fraction)
+		estimate.FeeShare = entities.NewPercent(share.Numerator, share.Denominator)
+	}
+	apr := estimate.FeesValue.Divide(estimate.PositionValue.Fraction).Multiply(entities.NewFraction(big.NewInt(int64(year)), big.NewInt(int64(period))))
+	estimate.APR = entities.NewPercent(apr.Numerator, apr.Denominator)
+	return estimate, nil
+}
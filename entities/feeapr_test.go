@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiquidityAtTick(t *testing.T) {
+	pool := multiRangePool(token0, token1)
+	for tick, expected := range map[int]int64{
+		0: 2500000, 59: 2500000, 60: 1500000, 100: 1500000, 500: 500000, 1500: 0,
+		-120: 2500000, -121: 500000, -200: 500000, -1300: 0, utils.MinTick: 0, utils.MaxTick - 1: 0,
+	} {
+		liquidity, err := pool.LiquidityAtTick(tick)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, big.NewInt(expected).Cmp(liquidity), "tick %d", tick)
+	}
+
+	noTicks, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(100), 0, nil)
+	assert.NoError(t, err)
+	liquidity, err := noTicks.LiquidityAtTick(0)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), liquidity)
+	_, err = noTicks.LiquidityAtTick(60)
+	assert.ErrorIs(t, err, ErrNoTickData)
+}
+
+func TestVolumeBucketFromSwap(t *testing.T) {
+	bucket := VolumeBucketFromSwap(big.NewInt(100), big.NewInt(-99), 5)
+	assert.Equal(t, VolumeBucket{Tick: 5, Volume0: big.NewInt(100), Volume1: constants.Zero}, bucket)
+	bucket = VolumeBucketFromSwap(big.NewInt(-99), big.NewInt(100), -5)
+	assert.Equal(t, VolumeBucket{Tick: -5, Volume0: constants.Zero, Volume1: big.NewInt(100)}, bucket)
+}
+
+func TestEstimateFeeAPR(t *testing.T) {
+	pool := multiRangePool(token0, token1)
+	liquidity := big.NewInt(2500000)
+	buckets := []VolumeBucket{
+		{Tick: 0, Volume0: big.NewInt(1000000), Volume1: big.NewInt(0)},
+		{Tick: 30, Volume0: big.NewInt(0), Volume1: big.NewInt(2000000)},
+		{Tick: 100, Volume0: big.NewInt(1000000), Volume1: big.NewInt(0)},
+	}
+
+	// earns half of the fees in range, where it doubles the liquidity
+	estimate, err := EstimateFeeAPR(pool, -60, 60, liquidity, buckets, 24*time.Hour, token1)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1500), estimate.Fees0.Quotient())
+	assert.Equal(t, big.NewInt(3000), estimate.Fees1.Quotient())
+	assert.True(t, estimate.FeesValue.EqualTo(entities.NewFraction(big.NewInt(4500), constants.One)))
+	assert.True(t, estimate.FeeShare.EqualTo(entities.NewFraction(big.NewInt(3), big.NewInt(8))))
+	position, _ := NewPosition(pool, liquidity, -60, 60)
+	amount0, amount1, _ := position.MintAmounts()
+	assert.True(t, estimate.PositionValue.EqualTo(entities.NewFraction(new(big.Int).Add(amount0, amount1), constants.One)))
+	apr := entities.NewFraction(big.NewInt(4500*365), new(big.Int).Add(amount0, amount1))
+	assert.True(t, estimate.APR.EqualTo(apr))
+
+	// earns a smaller share in a wider range, but from more of the volume
+	wide, err := EstimateFeeAPR(pool, -600, 600, liquidity, buckets, 24*time.Hour, token1)
+	assert.NoError(t, err)
+	assert.True(t, wide.Fees0.GreaterThan(estimate.Fees0.Fraction))
+	assert.True(t, wide.APR.LessThan(estimate.APR.Fraction))
+
+	// earns nothing out of range
+	none, err := EstimateFeeAPR(pool, 1200, 1800, liquidity, buckets, 24*time.Hour, token0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, none.FeesValue.Numerator.Sign())
+	assert.Equal(t, 0, none.APR.Numerator.Sign())
+
+	_, err = EstimateFeeAPR(pool, 60, -60, liquidity, buckets, time.Hour, token1)
+	assert.ErrorIs(t, err, ErrTickOrder)
+	_, err = EstimateFeeAPR(pool, -60, 60, big.NewInt(0), buckets, time.Hour, token1)
+	assert.ErrorIs(t, err, ErrZeroLiquidity)
+	_, err = EstimateFeeAPR(pool, -60, 60, liquidity, buckets, 0, token1)
+	assert.ErrorIs(t, err, ErrInvalidFeePeriod)
+	_, err = EstimateFeeAPR(pool, -60, 60, liquidity, buckets, time.Hour, token2)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+}
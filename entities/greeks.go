@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var ErrNoPositions = errors.New("no positions")
+
+// Greeks are the sensitivities of the value of liquidity, in terms of one token, to the price of the other token
+type Greeks struct {
+	Delta *entities.CurrencyAmount // The first derivative of the value with respect to the price, in units of the priced token
+	Gamma *entities.Fraction       // The second derivative of the value with respect to the price, in raw units, nil if not comparable
+	Hedge *entities.CurrencyAmount // The amount of the priced token to add, negative to sell short, to make the value delta-neutral
+}
+
+/**
+ * Computes the greeks of the position analytically from the concentrated liquidity formulas. With P the price of the
+ * token in terms of the other, the value is V = x P + y, whose delta is the amount x of the token that the position
+ * holds, and whose gamma is -L / (2 P^(3/2)) within the range and zero outside of it.
+ * @param sqrtPriceX96 The sqrt price of the pool to compute the greeks at, which need not be the pool's current price
+ * @param token The token whose price the derivatives are taken against, valued in the other pool token
+ * @returns The delta, gamma and delta-neutral hedge of the position
+ */
+func (p *Position) Greeks(sqrtPriceX96 *big.Int, token *entities.Token) (*Greeks, error) {
+	if !p.Pool.InvolvesToken(token) {
+		return nil, ErrTokenNotInvolved
+	}
+	amount0, amount1, err := p.AmountsAtPrice(sqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	sqrtRatioAX96, err := utils.GetSqrtRatioAtTick(p.TickLower)
+	if err != nil {
+		return nil, err
+	}
+	sqrtRatioBX96, err := utils.GetSqrtRatioAtTick(p.TickUpper)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := amount0
+	if token.Equal(p.Pool.Token1) {
+		delta = amount1
+	}
+
+	// the price of token0 is s^2 with s = sqrtPriceX96 / 2^96, and the price of token1 is its inverse
+	gamma := entities.NewFraction(constants.Zero, constants.One)
+	if sqrtPriceX96.Cmp(sqrtRatioAX96) > 0 && sqrtPriceX96.Cmp(sqrtRatioBX96) < 0 {
+		cube := new(big.Int).Exp(sqrtPriceX96, big.NewInt(3), nil)
+		q288 := new(big.Int).Exp(constants.Q96, big.NewInt(3), nil)
+		liquidity := new(big.Int).Neg(p.Liquidity)
+		if token.Equal(p.Pool.Token0) {
+			gamma = entities.NewFraction(new(big.Int).Mul(liquidity, q288), new(big.Int).Mul(big.NewInt(2), cube))
+		} else {
+			gamma = entities.NewFraction(new(big.Int).Mul(liquidity, cube), new(big.Int).Mul(big.NewInt(2), q288))
+		}
+	}
+
+	return &Greeks{
+		Delta: delta,
+		Gamma: gamma,
+		Hedge: entities.FromRawAmount(token, new(big.Int).Neg(delta.Quotient())),
+	}, nil
+}
+
+/**
+ * Computes the greeks of several positions to the price of a token, each at its pool's current price, e.g. to hedge
+ * a book of positions across fee tiers and pairs. The deltas and hedges always add up, while the gammas only add up
+ * when every pool prices the token in the same other token; otherwise the portfolio gamma is nil.
+ * @param positions The positions, all in pools involving the token
+ * @param token The token whose price the derivatives are taken against
+ * @returns The total delta, gamma and delta-neutral hedge of the positions
+ */
+func PortfolioGreeks(positions []*Position, token *entities.Token) (*Greeks, error) {
+	if len(positions) == 0 {
+		return nil, ErrNoPositions
+	}
+	total := &Greeks{
+		Delta: entities.FromRawAmount(token, constants.Zero),
+		Gamma: entities.NewFraction(constants.Zero, constants.One),
+		Hedge: entities.FromRawAmount(token, constants.Zero),
+	}
+	var quote *entities.Token
+	for _, position := range positions {
+		greeks, err := position.Greeks(position.Pool.SqrtRatioX96, token)
+		if err != nil {
+			return nil, err
+		}
+		total.Delta = total.Delta.Add(greeks.Delta)
+		total.Hedge = total.Hedge.Add(greeks.Hedge)
+
+		other := position.Pool.Token0
+		if other.Equal(token) {
+			other = position.Pool.Token1
+		}
+		if quote == nil {
+			quote = other
+		}
+		if total.Gamma != nil && other.Equal(quote) {
+			total.Gamma = total.Gamma.Add(greeks.Gamma)
+		} else {
+			total.Gamma = nil
+		}
+	}
+	return total, nil
+}
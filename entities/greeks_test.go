@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// toFloat converts a fraction to a float for approximate comparisons
+func toFloat(f *entities.Fraction) float64 {
+	v, _ := new(big.Rat).SetFrac(f.Numerator, f.Denominator).Float64()
+	return v
+}
+
+func TestGreeks(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	liquidity := new(big.Int).Mul(OneEther, big.NewInt(1000))
+	position, err := NewPosition(pool, liquidity, -600, 600)
+	assert.NoError(t, err)
+	sqrtPriceX96 := utils.EncodeSqrtRatioX96(big.NewInt(102), big.NewInt(100))
+
+	_, err = position.Greeks(sqrtPriceX96, token2)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+
+	for _, token := range []*entities.Token{token0, token1} {
+		greeks, err := position.Greeks(sqrtPriceX96, token)
+		assert.NoError(t, err)
+
+		// the delta is the amount of the token held, and the hedge sells it
+		amount0, amount1, err := position.AmountsAtPrice(sqrtPriceX96)
+		assert.NoError(t, err)
+		held := amount0
+		if token.Equal(token1) {
+			held = amount1
+		}
+		assert.True(t, greeks.Delta.EqualTo(held.Fraction))
+		assert.True(t, greeks.Hedge.Add(greeks.Delta).Numerator.Sign() == 0)
+		assert.True(t, greeks.Hedge.Currency.Equal(token))
+
+		// the delta and gamma match finite differences of the value and of the delta
+		quote := token1
+		if token.Equal(token1) {
+			quote = token0
+		}
+		price := func(sqrtPriceX96 *big.Int) float64 {
+			p := toFloat(entities.NewFraction(new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96), constants.Q192))
+			if token.Equal(token1) {
+				return 1 / p
+			}
+			return p
+		}
+		up := new(big.Int).Add(sqrtPriceX96, new(big.Int).Rsh(sqrtPriceX96, 20))
+		down := new(big.Int).Sub(sqrtPriceX96, new(big.Int).Rsh(sqrtPriceX96, 20))
+		valueUp, err := position.ValueAtSqrtPrice(up, quote)
+		assert.NoError(t, err)
+		valueDown, err := position.ValueAtSqrtPrice(down, quote)
+		assert.NoError(t, err)
+		dp := price(up) - price(down)
+		assert.InEpsilon(t, toFloat(greeks.Delta.Fraction), (toFloat(valueUp.Fraction)-toFloat(valueDown.Fraction))/dp, 1e-4)
+		greeksUp, err := position.Greeks(up, token)
+		assert.NoError(t, err)
+		greeksDown, err := position.Greeks(down, token)
+		assert.NoError(t, err)
+		assert.Less(t, toFloat(greeks.Gamma), 0.0)
+		assert.InEpsilon(t, toFloat(greeks.Gamma), (toFloat(greeksUp.Delta.Fraction)-toFloat(greeksDown.Delta.Fraction))/dp, 1e-4)
+	}
+
+	// has no gamma outside of the range
+	greeks, err := position.Greeks(utils.EncodeSqrtRatioX96(big.NewInt(2), big.NewInt(1)), token0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, greeks.Gamma.Numerator.Sign())
+	assert.Equal(t, 0, greeks.Delta.Numerator.Sign())
+}
+
+func TestPortfolioGreeks(t *testing.T) {
+	_, err := PortfolioGreeks(nil, token0)
+	assert.ErrorIs(t, err, ErrNoPositions)
+
+	medium, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	lowSqrtRatioX96 := utils.EncodeSqrtRatioX96(big.NewInt(101), big.NewInt(100))
+	lowTick, err := utils.GetTickAtSqrtRatio(lowSqrtRatioX96)
+	assert.NoError(t, err)
+	low, err := NewPool(token0, token1, constants.FeeLow, lowSqrtRatioX96, big.NewInt(0), lowTick, nil)
+	assert.NoError(t, err)
+	other, err := NewPool(token0, token2, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	a, _ := NewPosition(medium, OneEther, -600, 600)
+	b, _ := NewPosition(low, OneEther, -100, 300)
+	c, _ := NewPosition(other, OneEther, -1200, 1200)
+
+	// sums the greeks of positions pricing the token in the same token
+	total, err := PortfolioGreeks([]*Position{a, b}, token0)
+	assert.NoError(t, err)
+	greeksA, _ := a.Greeks(medium.SqrtRatioX96, token0)
+	greeksB, _ := b.Greeks(low.SqrtRatioX96, token0)
+	assert.True(t, total.Delta.EqualTo(greeksA.Delta.Add(greeksB.Delta).Fraction))
+	assert.True(t, total.Hedge.EqualTo(greeksA.Hedge.Add(greeksB.Hedge).Fraction))
+	assert.True(t, total.Gamma.EqualTo(greeksA.Gamma.Add(greeksB.Gamma)))
+
+	// sums only the deltas across different pairs
+	total, err = PortfolioGreeks([]*Position{a, b, c}, token0)
+	assert.NoError(t, err)
+	greeksC, _ := c.Greeks(other.SqrtRatioX96, token0)
+	assert.True(t, total.Delta.EqualTo(greeksA.Delta.Add(greeksB.Delta).Add(greeksC.Delta).Fraction))
+	assert.Nil(t, total.Gamma)
+
+	// throws for a position not involving the token
+	_, err = PortfolioGreeks([]*Position{a, c}, token1)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+}
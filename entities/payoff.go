@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var ErrInvalidCurveRange = errors.New("invalid payoff curve range")
+
+// PayoffPoint is the state of a position at one price of a payoff curve
+type PayoffPoint struct {
+	Tick         int                      // The tick of the price
+	SqrtPriceX96 *big.Int                 // The sqrt price at the tick
+	Price        *entities.Price          // The price of token0 in terms of token1
+	Amount0      *entities.CurrencyAmount // The amount of token0 the position could be burned for
+	Amount1      *entities.CurrencyAmount // The amount of token1 the position could be burned for
+	Value        *entities.CurrencyAmount // The value of both amounts in terms of the quote token
+}
+
+/**
+ * Samples the position's token amounts and value across a price range, evenly spaced in ticks
+ * so that the samples are evenly spaced in log price
+ * @param tickLower The tick of the lowest price to sample
+ * @param tickUpper The tick of the highest price to sample
+ * @param samples The number of prices to sample, at least 2, including both ends
+ * @param quote The token to value the position in
+ * @returns The points of the curve, from the lowest price to the highest
+ */
+func (p *Position) PayoffCurve(tickLower, tickUpper, samples int, quote *entities.Token) ([]*PayoffPoint, error) {
+	if tickLower >= tickUpper || samples < 2 || tickLower < utils.MinTick || tickUpper > utils.MaxTick {
+		return nil, ErrInvalidCurveRange
+	}
+	if !p.Pool.InvolvesToken(quote) {
+		return nil, ErrTokenNotInvolved
+	}
+
+	points := make([]*PayoffPoint, samples)
+	for i := range points {
+		tick := tickLower + int(int64(tickUpper-tickLower)*int64(i)/int64(samples-1))
+		sqrtPriceX96, err := utils.GetSqrtRatioAtTick(tick)
+		if err != nil {
+			return nil, err
+		}
+		price, err := utils.TickToPrice(p.Pool.Token0, p.Pool.Token1, tick)
+		if err != nil {
+			return nil, err
+		}
+		amount0, amount1, err := p.AmountsAtPrice(sqrtPriceX96)
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.Pool.valueAtSqrtPrice(sqrtPriceX96, amount0.Quotient(), amount1.Quotient(), quote)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = &PayoffPoint{
+			Tick:         tick,
+			SqrtPriceX96: sqrtPriceX96,
+			Price:        price,
+			Amount0:      amount0,
+			Amount1:      amount1,
+			Value:        value,
+		}
+	}
+	return points, nil
+}
+
+/**
+ * Writes a payoff curve as CSV, with a header row and one row per point. Prices, amounts and values are
+ * decimal strings adjusted for the token decimals.
+ * @param w The writer to write the CSV to
+ * @param points The points of the curve
+ */
+func WritePayoffCSV(w io.Writer, points []*PayoffPoint) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"tick", "sqrtPriceX96", "price", "amount0", "amount1", "value"}); err != nil {
+		return err
+	}
+	for _, point := range points {
+		err := writer.Write([]string{
+			strconv.Itoa(point.Tick),
+			point.SqrtPriceX96.String(),
+			point.Price.ToSignificant(12),
+			point.Amount0.ToExact(),
+			point.Amount1.ToExact(),
+			point.Value.ToFixed(int32(point.Value.Currency.Decimals())),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayoffCurve(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	position, err := NewPosition(pool, OneEther, -600, 600)
+	assert.NoError(t, err)
+
+	_, err = position.PayoffCurve(600, -600, 10, token1)
+	assert.ErrorIs(t, err, ErrInvalidCurveRange)
+	_, err = position.PayoffCurve(-600, 600, 1, token1)
+	assert.ErrorIs(t, err, ErrInvalidCurveRange)
+	_, err = position.PayoffCurve(-600, 600, 10, token2)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+
+	points, err := position.PayoffCurve(-1200, 1200, 9, token1)
+	assert.NoError(t, err)
+	assert.Len(t, points, 9)
+	assert.Equal(t, -1200, points[0].Tick)
+	assert.Equal(t, 1200, points[8].Tick)
+	for i, point := range points {
+		amount0, amount1, err := position.AmountsAtTick(point.Tick)
+		assert.NoError(t, err)
+		assert.Equal(t, amount0, point.Amount0)
+		assert.Equal(t, amount1, point.Amount1)
+		value, err := position.ValueAtSqrtPrice(point.SqrtPriceX96, token1)
+		assert.NoError(t, err)
+		assert.True(t, value.EqualTo(point.Value.Fraction))
+		if i > 0 {
+			// token0 is sold into token1 as the price rises, and the value in token1 never falls
+			assert.False(t, point.Amount0.GreaterThan(points[i-1].Amount0.Fraction))
+			assert.False(t, point.Value.LessThan(points[i-1].Value.Fraction))
+		}
+	}
+	// the position is all token0 below the range and all token1 above it
+	assert.Equal(t, 0, points[0].Amount1.Quotient().Sign())
+	assert.Equal(t, 0, points[8].Amount0.Quotient().Sign())
+
+	// samples the ends of the tick range
+	points, err = position.PayoffCurve(utils.MinTick, utils.MaxTick, 2, token0)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, utils.MaxSqrtRatio, points[1].SqrtPriceX96)
+	amount0, amount1, err := position.AmountsAtTick(utils.MaxTick)
+	assert.NoError(t, err)
+	assert.Equal(t, amount0, points[1].Amount0)
+	assert.Equal(t, amount1, points[1].Amount1)
+}
+
+func TestWritePayoffCSV(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	position, err := NewPosition(pool, OneEther, -600, 600)
+	assert.NoError(t, err)
+	points, err := position.PayoffCurve(-1200, 1200, 3, token1)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WritePayoffCSV(&buf, points))
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, []string{"tick", "sqrtPriceX96", "price", "amount0", "amount1", "value"}, records[0])
+	assert.Equal(t, "0", records[2][0])
+	assert.Equal(t, points[1].SqrtPriceX96.String(), records[2][1])
+	assert.Equal(t, "1", records[2][2])
+	assert.Equal(t, points[1].Amount0.ToExact(), records[2][3])
+	assert.Equal(t, "0", records[1][4])
+	assert.Equal(t, "0", records[3][3])
+}
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+)
+
+// PositionPnL compares a position with holding the tokens it was entered with, all valued in a quote token
+type PositionPnL struct {
+	EntryValue             *entities.CurrencyAmount // The value of the position at the entry price
+	HoldValue              *entities.CurrencyAmount // The value at the price of holding the amounts the position was entered with
+	PositionValue          *entities.CurrencyAmount // The value of the position at the price, excluding fees
+	ImpermanentLoss        *entities.CurrencyAmount // How much less the position is worth than holding, i.e. HoldValue - PositionValue
+	ImpermanentLossPercent *entities.Percent        // The impermanent loss as a share of the hold value
+	Fees                   *entities.CurrencyAmount // The value at the price of the fees owed to the position, nil if unknown
+	PnL                    *entities.CurrencyAmount // The change in value since entry including fees, i.e. PositionValue + Fees - EntryValue
+}
+
+/**
+ * Returns the value of the position at a price, in terms of one of the pool tokens
+ * @param sqrtPriceX96 The sqrt price to value the position at, which need not be the pool's current price
+ * @param quote The token to value the position in
+ * @returns The value of the amounts the position could be burned for at the price
+ */
+func (p *Position) ValueAtSqrtPrice(sqrtPriceX96 *big.Int, quote *entities.Token) (*entities.CurrencyAmount, error) {
+	amount0, amount1, err := p.AmountsAtPrice(sqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	return p.Pool.valueAtSqrtPrice(sqrtPriceX96, amount0.Quotient(), amount1.Quotient(), quote)
+}
+
+/**
+ * Computes the impermanent loss and PnL of the position since it was entered, compared with holding the amounts it
+ * was entered with. Fees are included when the position tracks the tokens owed to it, e.g. when read from the position manager.
+ * @param entrySqrtPriceX96 The sqrt price the position was entered at
+ * @param sqrtPriceX96 The sqrt price to evaluate at, which need not be the pool's current price
+ * @param quote The token to value everything in
+ * @returns The values at entry and at the price
+ */
+func (p *Position) PnL(entrySqrtPriceX96, sqrtPriceX96 *big.Int, quote *entities.Token) (*PositionPnL, error) {
+	entry0, entry1, err := p.AmountsAtPrice(entrySqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	entryValue, err := p.Pool.valueAtSqrtPrice(entrySqrtPriceX96, entry0.Quotient(), entry1.Quotient(), quote)
+	if err != nil {
+		return nil, err
+	}
+	holdValue, err := p.Pool.valueAtSqrtPrice(sqrtPriceX96, entry0.Quotient(), entry1.Quotient(), quote)
+	if err != nil {
+		return nil, err
+	}
+	positionValue, err := p.ValueAtSqrtPrice(sqrtPriceX96, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	impermanentLoss := holdValue.Subtract(positionValue)
+	impermanentLossPercent := entities.NewPercent(constants.Zero, constants.One)
+	if holdValue.Numerator.Sign() > 0 {
+		ratio := impermanentLoss.Divide(holdValue.Fraction)
+		impermanentLossPercent = entities.NewPercent(ratio.Numerator, ratio.Denominator)
+	}
+
+	pnl := positionValue.Subtract(entryValue)
+	var fees *entities.CurrencyAmount
+	if p.TokensOwed0 != nil && p.TokensOwed1 != nil {
+		fees, err = p.Pool.valueAtSqrtPrice(sqrtPriceX96, p.TokensOwed0, p.TokensOwed1, quote)
+		if err != nil {
+			return nil, err
+		}
+		pnl = pnl.Add(fees)
+	}
+
+	return &PositionPnL{
+		EntryValue:             entryValue,
+		HoldValue:              holdValue,
+		PositionValue:          positionValue,
+		ImpermanentLoss:        impermanentLoss,
+		ImpermanentLossPercent: impermanentLossPercent,
+		Fees:                   fees,
+		PnL:                    pnl,
+	}, nil
+}
+
+// valueAtSqrtPrice returns the value of amounts of the pool tokens in terms of one of them, at the given price
+func (p *Pool) valueAtSqrtPrice(sqrtPriceX96, amount0, amount1 *big.Int, quote *entities.Token) (*entities.CurrencyAmount, error) {
+	if !p.InvolvesToken(quote) {
+		return nil, ErrTokenNotInvolved
+	}
+	price := entities.NewPrice(p.Token0, p.Token1, constants.Q192, new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96))
+	base, other := entities.FromRawAmount(p.Token0, amount0), entities.FromRawAmount(p.Token1, amount1)
+	if quote.Equal(p.Token0) {
+		price = price.Invert()
+		base, other = other, base
+	}
+	quoted, err := price.Quote(base)
+	if err != nil {
+		return nil, err
+	}
+	return other.Add(quoted), nil
+}
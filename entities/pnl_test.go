@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueAtSqrtPrice(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	position, err := NewPosition(pool, OneEther, -600, 600)
+	assert.NoError(t, err)
+
+	// matches the amounts at the pool price, rounding the burned amounts down
+	value, err := position.ValueAtSqrtPrice(pool.SqrtRatioX96, token1)
+	assert.NoError(t, err)
+	amount0, _ := position.Amount0()
+	amount1, _ := position.Amount1()
+	assert.True(t, value.Currency.Equal(token1))
+	assert.Equal(t, new(big.Int).Add(amount0.Quotient(), amount1.Quotient()), new(big.Int).Add(value.Quotient(), constants.One))
+
+	// is entirely token0 below the range and token1 above it
+	below := utils.EncodeSqrtRatioX96(big.NewInt(1), big.NewInt(4))
+	value0, err := position.ValueAtSqrtPrice(below, token0)
+	assert.NoError(t, err)
+	value1, err := position.ValueAtSqrtPrice(below, token1)
+	assert.NoError(t, err)
+	assert.True(t, value1.EqualTo(value0.Divide(entities.NewFraction(big.NewInt(4), big.NewInt(1))).Fraction))
+	above, err := position.ValueAtSqrtPrice(utils.EncodeSqrtRatioX96(big.NewInt(4), big.NewInt(1)), token1)
+	assert.NoError(t, err)
+	sqrtLower, _ := utils.GetSqrtRatioAtTick(-600)
+	sqrtUpper, _ := utils.GetSqrtRatioAtTick(600)
+	assert.Equal(t, utils.GetAmount1Delta(sqrtLower, sqrtUpper, OneEther, false), above.Quotient())
+
+	_, err = position.ValueAtSqrtPrice(pool.SqrtRatioX96, token2)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+	_, err = position.ValueAtSqrtPrice(big.NewInt(1), token1)
+	assert.ErrorIs(t, err, ErrInvalidSqrtRatioX96)
+}
+
+func TestPositionPnL(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	position, err := NewPosition(pool, OneEther, -600, 600)
+	assert.NoError(t, err)
+	entry := pool.SqrtRatioX96
+
+	// nothing changes at the entry price
+	pnl, err := position.PnL(entry, entry, token1)
+	assert.NoError(t, err)
+	assert.True(t, pnl.EntryValue.EqualTo(pnl.HoldValue.Fraction))
+	assert.True(t, pnl.EntryValue.EqualTo(pnl.PositionValue.Fraction))
+	assert.Equal(t, 0, pnl.ImpermanentLoss.Numerator.Sign())
+	assert.Equal(t, 0, pnl.ImpermanentLossPercent.Numerator.Sign())
+	assert.Equal(t, 0, pnl.PnL.Numerator.Sign())
+	assert.Nil(t, pnl.Fees)
+
+	// loses more than a full range position would when the price moves
+	moved := utils.EncodeSqrtRatioX96(big.NewInt(10201), big.NewInt(10000))
+	pnl, err = position.PnL(entry, moved, token1)
+	assert.NoError(t, err)
+	assert.True(t, pnl.HoldValue.Subtract(pnl.PositionValue).EqualTo(pnl.ImpermanentLoss.Fraction))
+	fullRange := entities.NewPercent(big.NewInt(495), big.NewInt(10000000))
+	assert.True(t, pnl.ImpermanentLossPercent.GreaterThan(fullRange.Fraction))
+	assert.True(t, pnl.ImpermanentLossPercent.LessThan(entities.NewPercent(big.NewInt(1), big.NewInt(100)).Fraction))
+	assert.True(t, pnl.PnL.EqualTo(pnl.PositionValue.Subtract(pnl.EntryValue).Fraction))
+
+	// is the same loss in either token
+	pnl0, err := position.PnL(entry, moved, token0)
+	assert.NoError(t, err)
+	assert.True(t, pnl0.ImpermanentLossPercent.EqualTo(pnl.ImpermanentLossPercent.Fraction))
+
+	// includes the fees owed to the position
+	position.TokensOwed0 = big.NewInt(1e15)
+	position.TokensOwed1 = big.NewInt(2e15)
+	withFees, err := position.PnL(entry, moved, token1)
+	assert.NoError(t, err)
+	fees, err := pool.valueAtSqrtPrice(moved, position.TokensOwed0, position.TokensOwed1, token1)
+	assert.NoError(t, err)
+	assert.True(t, withFees.Fees.EqualTo(fees.Fraction))
+	assert.True(t, withFees.PnL.EqualTo(pnl.PnL.Add(fees).Fraction))
+	assert.True(t, withFees.ImpermanentLoss.EqualTo(pnl.ImpermanentLoss.Fraction))
+}
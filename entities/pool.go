@@ -16,6 +16,9 @@ var (
 	ErrTokenNotInvolved         = errors.New("Token not involved in pool")
 	ErrSqrtPriceLimitX96TooLow  = errors.New("SqrtPriceLimitX96 too low")
 	ErrSqrtPriceLimitX96TooHigh = errors.New("SqrtPriceLimitX96 too high")
+	ErrInsufficientInputAmount  = errors.New("insufficient input amount")
+	ErrInsufficientReserves     = errors.New("insufficient reserves")
+	ErrPriceLimitReached        = errors.New("price limit reached before fill")
 )
 
 type StepComputations struct {
@@ -146,17 +149,25 @@ func (p *Pool) ChainID() uint {
  * Given an input amount of a token, return the computed output amount, and a pool with state updated after the trade
  * @param inputAmount The input amount for which to quote the output amount
  * @param sqrtPriceLimitX96 The Q64.96 sqrt price limit
- * @returns The output amount and the pool with updated state
+ * @returns The output amount and the pool with updated state. If the pool cannot take the whole input, the output is
+ * for the part of the input it can take, like in the pool contract
+ * @returns ErrInsufficientInputAmount if there is no input, or if it is too small for any output
  */
 func (p *Pool) GetOutputAmount(inputAmount *entities.CurrencyAmount, sqrtPriceLimitX96 *big.Int) (*entities.CurrencyAmount, *Pool, error) {
 	if !(inputAmount.Currency.IsToken() && p.InvolvesToken(inputAmount.Currency.Wrapped())) {
 		return nil, nil, ErrTokenNotInvolved
 	}
+	if inputAmount.Quotient().Sign() <= 0 {
+		return nil, nil, ErrInsufficientInputAmount
+	}
 	zeroForOne := inputAmount.Currency.Equal(p.Token0)
 	outputAmount, sqrtRatioX96, liquidity, tickCurrent, err := p.swap(zeroForOne, inputAmount.Quotient(), sqrtPriceLimitX96)
 	if err != nil {
 		return nil, nil, err
 	}
+	if outputAmount.Sign() == 0 {
+		return nil, nil, ErrInsufficientInputAmount
+	}
 	var outputToken *entities.Token
 	if zeroForOne {
 		outputToken = p.Token1
@@ -175,6 +186,8 @@ func (p *Pool) GetOutputAmount(inputAmount *entities.CurrencyAmount, sqrtPriceLi
  * @param outputAmount the output amount for which to quote the input amount
  * @param sqrtPriceLimitX96 The Q64.96 sqrt price limit. If zero for one, the price cannot be less than this value after the swap. If one for zero, the price cannot be greater than this value after the swap
  * @returns The input amount and the pool with updated state
+ * @returns ErrInsufficientReserves if the pool does not have enough liquidity to fill the output, or ErrPriceLimitReached
+ * if the price limit is reached before the output is filled
  */
 func (p *Pool) GetInputAmount(outputAmount *entities.CurrencyAmount, sqrtPriceLimitX96 *big.Int) (*entities.CurrencyAmount, *Pool, error) {
 	if !(outputAmount.Currency.IsToken() && p.InvolvesToken(outputAmount.Currency.Wrapped())) {
@@ -209,6 +222,64 @@ func (p *Pool) GetInputAmount(outputAmount *entities.CurrencyAmount, sqrtPriceLi
  * @returns tickCurrent
  */
 func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int) (amountCalCulated *big.Int, sqrtRatioX96 *big.Int, liquidity *big.Int, tickCurrent int, err error) {
+	limitSpecified := sqrtPriceLimitX96 != nil
+	sqrtPriceLimitX96, err = p.checkedSqrtPriceLimit(zeroForOne, sqrtPriceLimitX96)
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+
+	// keep track of swap state
+	state := p.initialSwapState(amountSpecified)
+	if err := p.swapSteps(zeroForOne, state, sqrtPriceLimitX96, nil); err != nil {
+		return nil, nil, nil, 0, err
+	}
+	if err := state.checkFilled(amountSpecified, limitSpecified); err != nil {
+		return nil, nil, nil, 0, err
+	}
+	return state.amountCalculated, state.sqrtPriceX96, state.liquidity, state.tick, nil
+}
+
+// the state of a swap in progress
+type swapState struct {
+	amountSpecifiedRemaining *big.Int
+	amountCalculated         *big.Int
+	sqrtPriceX96             *big.Int
+	tick                     int
+	liquidity                *big.Int
+}
+
+/**
+ * Checks that a finished exact output swap filled the whole output. An exact input swap may stop early, in which case
+ * only part of the input is taken, like in the pool contract.
+ * @param amountSpecified The amount of the swap
+ * @param limitSpecified Whether the swap was given a price limit, rather than swapping as far as the price can move
+ */
+func (s *swapState) checkFilled(amountSpecified *big.Int, limitSpecified bool) error {
+	if amountSpecified.Sign() >= 0 || s.amountSpecifiedRemaining.Sign() == 0 {
+		return nil
+	}
+	if limitSpecified {
+		return ErrPriceLimitReached
+	}
+	return ErrInsufficientReserves
+}
+
+func (p *Pool) initialSwapState(amountSpecified *big.Int) *swapState {
+	return &swapState{
+		amountSpecifiedRemaining: amountSpecified,
+		amountCalculated:         constants.Zero,
+		sqrtPriceX96:             p.SqrtRatioX96,
+		tick:                     p.TickCurrent,
+		liquidity:                p.Liquidity,
+	}
+}
+
+/**
+ * Returns the price limit of a swap, defaulting to the furthest price the swap can move to
+ * @param zeroForOne Whether the amount in is token0 or token1
+ * @param sqrtPriceLimitX96 The optional Q64.96 sqrt price limit
+ */
+func (p *Pool) checkedSqrtPriceLimit(zeroForOne bool, sqrtPriceLimitX96 *big.Int) (*big.Int, error) {
 	if sqrtPriceLimitX96 == nil {
 		if zeroForOne {
 			sqrtPriceLimitX96 = new(big.Int).Add(utils.MinSqrtRatio, constants.One)
@@ -219,40 +290,39 @@ func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int
 
 	if zeroForOne {
 		if sqrtPriceLimitX96.Cmp(utils.MinSqrtRatio) <= 0 {
-			return nil, nil, nil, 0, ErrSqrtPriceLimitX96TooLow
+			return nil, ErrSqrtPriceLimitX96TooLow
 		}
 		if sqrtPriceLimitX96.Cmp(p.SqrtRatioX96) >= 0 {
-			return nil, nil, nil, 0, ErrSqrtPriceLimitX96TooHigh
+			return nil, ErrSqrtPriceLimitX96TooHigh
 		}
 	} else {
 		if sqrtPriceLimitX96.Cmp(utils.MaxSqrtRatio) >= 0 {
-			return nil, nil, nil, 0, ErrSqrtPriceLimitX96TooHigh
+			return nil, ErrSqrtPriceLimitX96TooHigh
 		}
 		if sqrtPriceLimitX96.Cmp(p.SqrtRatioX96) <= 0 {
-			return nil, nil, nil, 0, ErrSqrtPriceLimitX96TooLow
+			return nil, ErrSqrtPriceLimitX96TooLow
 		}
 	}
+	return sqrtPriceLimitX96, nil
+}
 
-	exactInput := amountSpecified.Cmp(constants.Zero) >= 0
-
-	// keep track of swap state
-
-	state := struct {
-		amountSpecifiedRemaining *big.Int
-		amountCalculated         *big.Int
-		sqrtPriceX96             *big.Int
-		tick                     int
-		liquidity                *big.Int
-	}{
-		amountSpecifiedRemaining: amountSpecified,
-		amountCalculated:         constants.Zero,
-		sqrtPriceX96:             p.SqrtRatioX96,
-		tick:                     p.TickCurrent,
-		liquidity:                p.Liquidity,
-	}
+/**
+ * Runs the steps of a swap from the given state until the amount specified is used up or the price limit is reached
+ * @param zeroForOne Whether the amount in is token0 or token1
+ * @param state The state to swap from, updated in place
+ * @param sqrtPriceLimitX96 The Q64.96 sqrt price limit
+ * @param onStep If not nil, called with a copy of the state before every step
+ */
+func (p *Pool) swapSteps(zeroForOne bool, state *swapState, sqrtPriceLimitX96 *big.Int, onStep func(swapState)) error {
+	var err error
+	exactInput := state.amountSpecifiedRemaining.Cmp(constants.Zero) >= 0
 
 	// start swap while loop
 	for state.amountSpecifiedRemaining.Cmp(constants.Zero) != 0 && state.sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		if onStep != nil {
+			onStep(*state)
+		}
+
 		var step StepComputations
 		step.sqrtPriceStartX96 = state.sqrtPriceX96
 
@@ -269,7 +339,7 @@ func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int
 
 		step.sqrtPriceNextX96, err = utils.GetSqrtRatioAtTick(step.tickNext)
 		if err != nil {
-			return nil, nil, nil, 0, err
+			return err
 		}
 		var targetValue *big.Int
 		if zeroForOne {
@@ -288,7 +358,7 @@ func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int
 
 		state.sqrtPriceX96, step.amountIn, step.amountOut, step.feeAmount, err = utils.ComputeSwapStep(state.sqrtPriceX96, targetValue, state.liquidity, state.amountSpecifiedRemaining, p.Fee)
 		if err != nil {
-			return nil, nil, nil, 0, err
+			return err
 		}
 
 		if exactInput {
@@ -320,11 +390,11 @@ func (p *Pool) swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int
 			// recompute unless we're on a lower tick boundary (i.e. already transitioned ticks), and haven't moved
 			state.tick, err = utils.GetTickAtSqrtRatio(state.sqrtPriceX96)
 			if err != nil {
-				return nil, nil, nil, 0, err
+				return err
 			}
 		}
 	}
-	return state.amountCalculated, state.sqrtPriceX96, state.liquidity, state.tick, nil
+	return nil
 }
 
 func (p *Pool) tickSpacing() int {
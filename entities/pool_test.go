@@ -180,3 +180,31 @@ func TestGetInputAmount(t *testing.T) {
 	assert.True(t, inputAmount.Currency.Equal(DAI))
 	assert.Equal(t, inputAmount.Quotient(), big.NewInt(100))
 }
+
+func TestSwapFillErrors(t *testing.T) {
+	pool := newTestPool()
+	limit := new(big.Int).Add(pool.SqrtRatioX96, big.NewInt(1))
+
+	_, _, err := pool.GetInputAmount(entities.FromRawAmount(DAI, new(big.Int).Mul(OneEther, big.NewInt(2))), nil)
+	assert.ErrorIs(t, err, ErrInsufficientReserves, "throws if the output exceeds the pool reserves")
+
+	_, _, err = pool.GetInputAmount(entities.FromRawAmount(DAI, big.NewInt(98)), limit)
+	assert.ErrorIs(t, err, ErrPriceLimitReached, "throws if the price limit is reached before the output is filled")
+
+	// exact input takes only what the pool can
+	outputAmount, _, err := pool.GetOutputAmount(entities.FromRawAmount(USDC, new(big.Int).Mul(OneEther, big.NewInt(2))), nil)
+	assert.NoError(t, err)
+	assert.True(t, outputAmount.LessThan(entities.FromRawAmount(DAI, OneEther).Fraction))
+
+	// exact input stops at the price limit
+	_, halfway, err := pool.GetInputAmount(entities.FromRawAmount(DAI, big.NewInt(49)), nil)
+	assert.NoError(t, err)
+	outputAmount, _, err = pool.GetOutputAmount(entities.FromRawAmount(USDC, big.NewInt(100)), halfway.SqrtRatioX96)
+	assert.NoError(t, err)
+	assert.True(t, outputAmount.LessThan(entities.FromRawAmount(DAI, big.NewInt(98)).Fraction))
+
+	_, _, err = pool.GetOutputAmount(entities.FromRawAmount(USDC, big.NewInt(0)), nil)
+	assert.ErrorIs(t, err, ErrInsufficientInputAmount, "throws for no input")
+	_, _, err = pool.GetOutputAmount(entities.FromRawAmount(USDC, big.NewInt(100)), limit)
+	assert.ErrorIs(t, err, ErrInsufficientInputAmount, "throws if the input buys no output")
+}
@@ -22,6 +22,12 @@ type Position struct {
 	TickUpper int
 	Liquidity *big.Int
 
+	// fee tracking as of the last action on the position, only known for positions read from the position manager
+	FeeGrowthInside0LastX128 *big.Int
+	FeeGrowthInside1LastX128 *big.Int
+	TokensOwed0              *big.Int
+	TokensOwed1              *big.Int
+
 	// cached resuts for the getters
 	token0Amount *entities.CurrencyAmount
 	token1Amount *entities.CurrencyAmount
@@ -116,6 +122,74 @@ func (p *Position) Amount1() (*entities.CurrencyAmount, error) {
 	return p.token1Amount, nil
 }
 
+/**
+ * Returns the amounts of token0 and token1 that this position's liquidity could be burned for at any price,
+ * not only the pool's current one
+ * @param sqrtPriceX96 The sqrt price to compute the amounts at, from the price of MinTick to the price of MaxTick
+ * @returns The amount of token0 and the amount of token1, rounded down
+ */
+func (p *Position) AmountsAtPrice(sqrtPriceX96 *big.Int) (amount0, amount1 *entities.CurrencyAmount, err error) {
+	if sqrtPriceX96.Cmp(utils.MinSqrtRatio) < 0 || sqrtPriceX96.Cmp(utils.MaxSqrtRatio) > 0 {
+		return nil, nil, ErrInvalidSqrtRatioX96
+	}
+	sqrtRatioAX96, err := utils.GetSqrtRatioAtTick(p.TickLower)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtRatioBX96, err := utils.GetSqrtRatioAtTick(p.TickUpper)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// clamp the price to the range, beyond which the position is entirely in one token
+	sqrtRatioX96 := sqrtPriceX96
+	if sqrtRatioX96.Cmp(sqrtRatioAX96) < 0 {
+		sqrtRatioX96 = sqrtRatioAX96
+	} else if sqrtRatioX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioX96 = sqrtRatioBX96
+	}
+	amount0 = entities.FromRawAmount(p.Pool.Token0, utils.GetAmount0Delta(sqrtRatioX96, sqrtRatioBX96, p.Liquidity, false))
+	amount1 = entities.FromRawAmount(p.Pool.Token1, utils.GetAmount1Delta(sqrtRatioAX96, sqrtRatioX96, p.Liquidity, false))
+	return amount0, amount1, nil
+}
+
+/**
+ * Returns the amounts of token0 and token1 that this position's liquidity could be burned for at the price of a tick
+ * @param tick The tick to compute the amounts at
+ * @returns The amount of token0 and the amount of token1, rounded down
+ */
+func (p *Position) AmountsAtTick(tick int) (amount0, amount1 *entities.CurrencyAmount, err error) {
+	sqrtRatioX96, err := utils.GetSqrtRatioAtTick(tick)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.AmountsAtPrice(sqrtRatioX96)
+}
+
+/**
+ * Returns the fees owed to the position: the tokens owed as of its last update plus the fees it earned since, like the
+ * position manager computes when collecting
+ * @param feeGrowthInside0X128 The current fee growth inside the position's range of token0, e.g. from utils.GetFeeGrowthInside
+ * @param feeGrowthInside1X128 The current fee growth inside the position's range of token1
+ * @returns The fees owed of token0 and of token1. Only the tokens owed are counted if the current fee growth or the
+ * fee growth of the last update is unknown
+ */
+func (p *Position) FeesOwed(feeGrowthInside0X128, feeGrowthInside1X128 *big.Int) (fees0, fees1 *entities.CurrencyAmount) {
+	owed0, owed1 := constants.Zero, constants.Zero
+	if p.TokensOwed0 != nil {
+		owed0 = p.TokensOwed0
+	}
+	if p.TokensOwed1 != nil {
+		owed1 = p.TokensOwed1
+	}
+	if feeGrowthInside0X128 != nil && feeGrowthInside1X128 != nil && p.FeeGrowthInside0LastX128 != nil && p.FeeGrowthInside1LastX128 != nil {
+		earned0, earned1 := utils.GetTokensOwed(p.FeeGrowthInside0LastX128, p.FeeGrowthInside1LastX128, p.Liquidity, feeGrowthInside0X128, feeGrowthInside1X128)
+		owed0 = new(big.Int).Add(owed0, earned0)
+		owed1 = new(big.Int).Add(owed1, earned1)
+	}
+	return entities.FromRawAmount(p.Pool.Token0, owed0), entities.FromRawAmount(p.Pool.Token1, owed1)
+}
+
 /**
  * Returns the lower and upper sqrt ratios if the price 'slips' up to slippage tolerance percentage
  * @param slippageTolerance The amount by which the price can 'slip' before the transaction will revert
@@ -356,3 +356,50 @@ func TestMintAmounts(t *testing.T) {
 	assert.Equal(t, "120054069145287995769397", amount0.String())
 	assert.Equal(t, "79831926243", amount1.String())
 }
+
+func TestAmountsAtPrice(t *testing.T) {
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	position, err := NewPosition(pool, OneEther, -600, 600)
+	assert.NoError(t, err)
+	sqrtLower, _ := utils.GetSqrtRatioAtTick(-600)
+	sqrtUpper, _ := utils.GetSqrtRatioAtTick(600)
+
+	// matches the burn amounts at the pool price
+	amount0, amount1, err := position.AmountsAtPrice(pool.SqrtRatioX96)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.GetAmount0Delta(pool.SqrtRatioX96, sqrtUpper, OneEther, false), amount0.Quotient())
+	current1, _ := position.Amount1()
+	assert.Equal(t, current1.Quotient(), amount1.Quotient())
+
+	// is entirely token0 below the range
+	amount0, amount1, err = position.AmountsAtPrice(utils.EncodeSqrtRatioX96(big.NewInt(1), big.NewInt(2)))
+	assert.NoError(t, err)
+	assert.Equal(t, utils.GetAmount0Delta(sqrtLower, sqrtUpper, OneEther, false), amount0.Quotient())
+	assert.Equal(t, 0, amount1.Quotient().Sign())
+
+	// is entirely token1 above the range
+	amount0, amount1, err = position.AmountsAtTick(1200)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, amount0.Quotient().Sign())
+	assert.Equal(t, utils.GetAmount1Delta(sqrtLower, sqrtUpper, OneEther, false), amount1.Quotient())
+
+	// matches the price of the tick
+	sqrtRatio, _ := utils.GetSqrtRatioAtTick(300)
+	amount0, amount1, err = position.AmountsAtTick(300)
+	assert.NoError(t, err)
+	expected0, expected1, _ := position.AmountsAtPrice(sqrtRatio)
+	assert.Equal(t, expected0, amount0)
+	assert.Equal(t, expected1, amount1)
+
+	// is entirely token1 at the max tick
+	amount0, amount1, err = position.AmountsAtTick(utils.MaxTick)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, amount0.Quotient().Sign())
+	assert.Equal(t, utils.GetAmount1Delta(sqrtLower, sqrtUpper, OneEther, false), amount1.Quotient())
+
+	_, _, err = position.AmountsAtPrice(new(big.Int).Add(utils.MaxSqrtRatio, big.NewInt(1)))
+	assert.ErrorIs(t, err, ErrInvalidSqrtRatioX96)
+	_, _, err = position.AmountsAtTick(utils.MaxTick + 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,285 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var (
+	ErrInvalidPrice      = errors.New("invalid price")
+	ErrInvalidPriceRange = errors.New("invalid price range")
+)
+
+// TickRounding is how price bounds are snapped to the ticks usable for a pool's tick spacing
+type TickRounding int
+
+const (
+	RoundNearest TickRounding = iota // Snap each bound to the nearest usable tick
+	RoundOutward                     // Snap the bounds away from each other, so the range contains the prices
+	RoundInward                      // Snap the bounds towards each other, so the prices contain the range
+)
+
+// PriceRange is a range of prices snapped to usable ticks
+type PriceRange struct {
+	TickLower  int             // The lower tick of the range
+	TickUpper  int             // The upper tick of the range
+	PriceLower *entities.Price // The lower price bound actually used, in terms of the prices given
+	PriceUpper *entities.Price // The upper price bound actually used, in terms of the prices given
+}
+
+/**
+ * Parses a human readable price, e.g. "1800.5" USDC per ETH, into a price between the raw amounts of the tokens
+ * @param baseToken The base token of the price, e.g. ETH
+ * @param quoteToken The quote token of the price, e.g. USDC
+ * @param price The decimal amount of quote token per whole base token
+ * @returns The price
+ */
+func ParsePrice(baseToken, quoteToken *entities.Token, price string) (*entities.Price, error) {
+	r, ok := new(big.Rat).SetString(price)
+	if !ok || r.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	numerator := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(quoteToken.Decimals())), nil))
+	denominator := new(big.Int).Mul(r.Denom(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(baseToken.Decimals())), nil))
+	return entities.NewPrice(baseToken, quoteToken, denominator, numerator), nil
+}
+
+/**
+ * Snaps a range of prices to the ticks usable in a pool
+ * @param pool The pool of the range
+ * @param priceLower The lower price, of either pool token in terms of the other
+ * @param priceUpper The upper price, in the same terms as the lower price
+ * @param rounding How to snap the prices to usable ticks
+ * @returns The ticks of the range and the prices at them
+ */
+func NewPriceRange(pool *Pool, priceLower, priceUpper *entities.Price, rounding TickRounding) (*PriceRange, error) {
+	baseToken, ok := priceLower.BaseCurrency.(*entities.Token)
+	if !ok || !pool.InvolvesToken(baseToken) {
+		return nil, ErrTokenNotInvolved
+	}
+	quoteToken, ok := priceLower.QuoteCurrency.(*entities.Token)
+	if !ok || !pool.InvolvesToken(quoteToken) || quoteToken.Equal(baseToken) {
+		return nil, ErrTokenNotInvolved
+	}
+	if !priceUpper.BaseCurrency.Equal(baseToken) || !priceUpper.QuoteCurrency.Equal(quoteToken) {
+		return nil, ErrInvalidPriceRange
+	}
+	if priceLower.Numerator.Sign() <= 0 || priceUpper.Numerator.Sign() <= 0 || !priceLower.LessThan(priceUpper.Fraction) {
+		return nil, ErrInvalidPriceRange
+	}
+
+	// prices of token1 in terms of token0 fall as the tick rises
+	lowerFirst := baseToken.Equal(pool.Token0)
+	priceA, priceB := priceLower, priceUpper
+	if !lowerFirst {
+		priceA, priceB = priceUpper, priceLower
+	}
+	tickLower, err := snapPrice(pool, priceA, baseToken, quoteToken, rounding, false)
+	if err != nil {
+		return nil, err
+	}
+	tickUpper, err := snapPrice(pool, priceB, baseToken, quoteToken, rounding, true)
+	if err != nil {
+		return nil, err
+	}
+	if tickLower >= tickUpper {
+		return nil, ErrInvalidPriceRange
+	}
+
+	priceAtLower, err := utils.TickToPrice(baseToken, quoteToken, tickLower)
+	if err != nil {
+		return nil, err
+	}
+	priceAtUpper, err := utils.TickToPrice(baseToken, quoteToken, tickUpper)
+	if err != nil {
+		return nil, err
+	}
+	if !lowerFirst {
+		priceAtLower, priceAtUpper = priceAtUpper, priceAtLower
+	}
+	return &PriceRange{
+		TickLower:  tickLower,
+		TickUpper:  tickUpper,
+		PriceLower: priceAtLower,
+		PriceUpper: priceAtUpper,
+	}, nil
+}
+
+/**
+ * Snaps a range of human readable prices, e.g. "1800.5" USDC per ETH, to the ticks usable in a pool
+ * @param pool The pool of the range
+ * @param baseToken The base token of the prices
+ * @param quoteToken The quote token of the prices
+ * @param priceLower The lower decimal price
+ * @param priceUpper The upper decimal price
+ * @param rounding How to snap the prices to usable ticks
+ * @returns The ticks of the range and the prices at them
+ */
+func NewPriceRangeFromStrings(pool *Pool, baseToken, quoteToken *entities.Token, priceLower, priceUpper string, rounding TickRounding) (*PriceRange, error) {
+	lower, err := ParsePrice(baseToken, quoteToken, priceLower)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := ParsePrice(baseToken, quoteToken, priceUpper)
+	if err != nil {
+		return nil, err
+	}
+	return NewPriceRange(pool, lower, upper, rounding)
+}
+
+/**
+ * Constructs a position for a given pool with the given liquidity, in a range of prices
+ * @param pool For which pool the liquidity is assigned
+ * @param liquidity The amount of liquidity that is in the position
+ * @param priceLower The lower price of the position
+ * @param priceUpper The upper price of the position
+ * @param rounding How to snap the prices to usable ticks
+ * @returns The position and the range it was constructed with
+ */
+func NewPositionFromPrices(pool *Pool, liquidity *big.Int, priceLower, priceUpper *entities.Price, rounding TickRounding) (*Position, *PriceRange, error) {
+	priceRange, err := NewPriceRange(pool, priceLower, priceUpper, rounding)
+	if err != nil {
+		return nil, nil, err
+	}
+	position, err := NewPosition(pool, liquidity, priceRange.TickLower, priceRange.TickUpper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return position, priceRange, nil
+}
+
+/**
+ * Computes the maximum amount of liquidity received for a given amount of token0 and token1 in a range of prices
+ * @param pool The pool for which the position should be created
+ * @param priceLower The lower price of the position
+ * @param priceUpper The upper price of the position
+ * @param rounding How to snap the prices to usable ticks
+ * @param amount0 token0 amount
+ * @param amount1 token1 amount
+ * @param useFullPrecision If false, liquidity will be maximized according to what the router can calculate,
+ * not what core can theoretically support
+ * @returns The position and the range it was constructed with
+ */
+func FromAmountsWithPrices(pool *Pool, priceLower, priceUpper *entities.Price, rounding TickRounding, amount0, amount1 *big.Int, useFullPrecision bool) (*Position, *PriceRange, error) {
+	priceRange, err := NewPriceRange(pool, priceLower, priceUpper, rounding)
+	if err != nil {
+		return nil, nil, err
+	}
+	position, err := FromAmounts(pool, priceRange.TickLower, priceRange.TickUpper, amount0, amount1, useFullPrecision)
+	if err != nil {
+		return nil, nil, err
+	}
+	return position, priceRange, nil
+}
+
+/**
+ * Computes a position with the maximum amount of liquidity received for a given amount of token0, in a range of prices
+ * @param pool The pool for which the position is created
+ * @param priceLower The lower price of the position
+ * @param priceUpper The upper price of the position
+ * @param rounding How to snap the prices to usable ticks
+ * @param amount0 The desired amount of token0
+ * @param useFullPrecision If true, liquidity will be maximized according to what the router can calculate,
+ * not what core can theoretically support
+ * @returns The position and the range it was constructed with
+ */
+func FromAmount0WithPrices(pool *Pool, priceLower, priceUpper *entities.Price, rounding TickRounding, amount0 *big.Int, useFullPrecision bool) (*Position, *PriceRange, error) {
+	priceRange, err := NewPriceRange(pool, priceLower, priceUpper, rounding)
+	if err != nil {
+		return nil, nil, err
+	}
+	position, err := FromAmount0(pool, priceRange.TickLower, priceRange.TickUpper, amount0, useFullPrecision)
+	if err != nil {
+		return nil, nil, err
+	}
+	return position, priceRange, nil
+}
+
+/**
+ * Computes a position with the maximum amount of liquidity received for a given amount of token1, in a range of prices
+ * @param pool The pool for which the position is created
+ * @param priceLower The lower price of the position
+ * @param priceUpper The upper price of the position
+ * @param rounding How to snap the prices to usable ticks
+ * @param amount1 The desired amount of token1
+ * @returns The position and the range it was constructed with
+ */
+func FromAmount1WithPrices(pool *Pool, priceLower, priceUpper *entities.Price, rounding TickRounding, amount1 *big.Int) (*Position, *PriceRange, error) {
+	priceRange, err := NewPriceRange(pool, priceLower, priceUpper, rounding)
+	if err != nil {
+		return nil, nil, err
+	}
+	position, err := FromAmount1(pool, priceRange.TickLower, priceRange.TickUpper, amount1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return position, priceRange, nil
+}
+
+// snapPrice returns the usable tick for a price bound, rounding outward or inward depending on whether it is the upper tick
+func snapPrice(pool *Pool, price *entities.Price, baseToken, quoteToken *entities.Token, rounding TickRounding, upper bool) (int, error) {
+	tickSpacing := pool.tickSpacing()
+	minTick := utils.MinTick / tickSpacing * tickSpacing
+	maxTick := utils.MaxTick / tickSpacing * tickSpacing
+
+	// prices beyond the ticks of any pool snap to the usable bounds
+	sqrtRatioX96 := utils.EncodeSqrtRatioX96(price.Numerator, price.Denominator)
+	if baseToken.Equal(pool.Token1) {
+		sqrtRatioX96 = utils.EncodeSqrtRatioX96(price.Denominator, price.Numerator)
+	}
+	if sqrtRatioX96.Cmp(utils.MinSqrtRatio) < 0 {
+		return minTick, nil
+	}
+	if sqrtRatioX96.Cmp(utils.MaxSqrtRatio) >= 0 {
+		return maxTick, nil
+	}
+	tick, err := utils.PriceToClosestTick(price, baseToken, quoteToken)
+	if err != nil {
+		return 0, err
+	}
+	if tick < minTick {
+		return minTick, nil
+	}
+	if tick > maxTick {
+		return maxTick, nil
+	}
+
+	// the price lies in [tick, tick + 1) in tick space, so round up from above the tick unless it is exactly at it
+	floor := floorToSpacing(tick, tickSpacing)
+	ceil := floor
+	atTick, err := utils.TickToPrice(baseToken, quoteToken, tick)
+	if err != nil {
+		return 0, err
+	}
+	if tick != floor || !atTick.EqualTo(price.Fraction) {
+		ceil = floor + tickSpacing
+	}
+
+	var snapped int
+	switch {
+	case rounding == RoundNearest:
+		snapped = NearestUsableTick(tick, tickSpacing)
+	case (rounding == RoundOutward) == upper:
+		snapped = ceil
+	default:
+		snapped = floor
+	}
+	if snapped < minTick {
+		snapped = minTick
+	} else if snapped > maxTick {
+		snapped = maxTick
+	}
+	return snapped, nil
+}
+
+// floorToSpacing returns the greatest multiple of the tick spacing at or below the tick
+func floorToSpacing(tick, tickSpacing int) int {
+	floor := tick / tickSpacing * tickSpacing
+	if floor > tick {
+		floor -= tickSpacing
+	}
+	return floor
+}
@@ -0,0 +1,146 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func usdcWethPool(t *testing.T) *Pool {
+	sqrtRatioX96 := utils.EncodeSqrtRatioX96(OneEther, big.NewInt(1800e6))
+	tick, err := utils.GetTickAtSqrtRatio(sqrtRatioX96)
+	assert.NoError(t, err)
+	pool, err := NewPool(USDC, entities.WETH9[1], constants.FeeMedium, sqrtRatioX96, big.NewInt(0), tick, nil)
+	assert.NoError(t, err)
+	return pool
+}
+
+func TestParsePrice(t *testing.T) {
+	weth := entities.WETH9[1]
+	price, err := ParsePrice(weth, USDC, "1800.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "1800.5", price.ToSignificant(5))
+	assert.True(t, price.EqualTo(entities.NewFraction(big.NewInt(18005e5), OneEther)))
+
+	price, err = ParsePrice(USDC, weth, "0.0005")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0005", price.ToSignificant(1))
+
+	for _, invalid := range []string{"", "abc", "0", "-1"} {
+		_, err = ParsePrice(weth, USDC, invalid)
+		assert.ErrorIs(t, err, ErrInvalidPrice)
+	}
+}
+
+func TestNewPriceRange(t *testing.T) {
+	pool := usdcWethPool(t)
+	weth := entities.WETH9[1]
+	lower, _ := ParsePrice(weth, USDC, "1500")
+	upper, _ := ParsePrice(weth, USDC, "2500")
+
+	// contains the prices when rounding outward
+	r, err := NewPriceRange(pool, lower, upper, RoundOutward)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, r.TickLower%60)
+	assert.Equal(t, 0, r.TickUpper%60)
+	assert.True(t, r.TickLower < r.TickUpper)
+	assert.False(t, r.PriceLower.GreaterThan(lower.Fraction))
+	assert.False(t, r.PriceUpper.LessThan(upper.Fraction))
+	assert.True(t, r.PriceLower.BaseCurrency.Equal(weth))
+
+	// lies within the prices when rounding inward
+	inward, err := NewPriceRange(pool, lower, upper, RoundInward)
+	assert.NoError(t, err)
+	assert.False(t, inward.PriceLower.LessThan(lower.Fraction))
+	assert.False(t, inward.PriceUpper.GreaterThan(upper.Fraction))
+	assert.Equal(t, r.TickLower+60, inward.TickLower)
+	assert.Equal(t, r.TickUpper-60, inward.TickUpper)
+
+	// snaps each bound to the nearest usable tick
+	nearest, err := NewPriceRange(pool, lower, upper, RoundNearest)
+	assert.NoError(t, err)
+	tickA, _ := utils.PriceToClosestTick(upper, weth, USDC)
+	tickB, _ := utils.PriceToClosestTick(lower, weth, USDC)
+	assert.Equal(t, NearestUsableTick(tickA, 60), nearest.TickLower)
+	assert.Equal(t, NearestUsableTick(tickB, 60), nearest.TickUpper)
+
+	// keeps prices that are exactly at usable ticks
+	atLower, _ := utils.TickToPrice(weth, USDC, 201000)
+	atUpper, _ := utils.TickToPrice(weth, USDC, 199980)
+	for _, rounding := range []TickRounding{RoundNearest, RoundOutward, RoundInward} {
+		exact, err := NewPriceRange(pool, atLower, atUpper, rounding)
+		assert.NoError(t, err)
+		assert.Equal(t, 199980, exact.TickLower)
+		assert.Equal(t, 201000, exact.TickUpper)
+		assert.True(t, exact.PriceLower.EqualTo(atLower.Fraction))
+		assert.True(t, exact.PriceUpper.EqualTo(atUpper.Fraction))
+	}
+
+	// accepts prices of token0 in terms of token1
+	inverted, err := NewPriceRange(pool, upper.Invert(), lower.Invert(), RoundOutward)
+	assert.NoError(t, err)
+	assert.Equal(t, r.TickLower, inverted.TickLower)
+	assert.Equal(t, r.TickUpper, inverted.TickUpper)
+	assert.True(t, inverted.PriceLower.EqualTo(r.PriceUpper.Invert().Fraction))
+
+	// parses decimal strings
+	fromStrings, err := NewPriceRangeFromStrings(pool, weth, USDC, "1500", "2500", RoundOutward)
+	assert.NoError(t, err)
+	assert.Equal(t, r, fromStrings)
+	_, err = NewPriceRangeFromStrings(pool, weth, USDC, "1500", "x", RoundOutward)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+
+	// throws for invalid ranges
+	_, err = NewPriceRange(pool, upper, lower, RoundOutward)
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+	_, err = NewPriceRange(pool, lower, upper.Invert(), RoundOutward)
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+	_, err = NewPriceRangeFromStrings(pool, weth, USDC, "1800", "1800.01", RoundInward)
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+	_, err = NewPriceRangeFromStrings(pool, weth, DAI, "1500", "2500", RoundOutward)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+
+	// clamps to the usable tick bounds
+	wide, err := NewPriceRangeFromStrings(pool, weth, USDC, "1e-30", "1e60", RoundOutward)
+	assert.NoError(t, err)
+	assert.Equal(t, -887220, wide.TickLower)
+	assert.Equal(t, 887220, wide.TickUpper)
+}
+
+func TestPositionFromPrices(t *testing.T) {
+	pool := usdcWethPool(t)
+	weth := entities.WETH9[1]
+	lower, _ := ParsePrice(weth, USDC, "1500")
+	upper, _ := ParsePrice(weth, USDC, "2500")
+	r, err := NewPriceRange(pool, lower, upper, RoundOutward)
+	assert.NoError(t, err)
+
+	position, positionRange, err := NewPositionFromPrices(pool, OneEther, lower, upper, RoundOutward)
+	assert.NoError(t, err)
+	assert.Equal(t, r, positionRange)
+	assert.Equal(t, r.TickLower, position.TickLower)
+	assert.Equal(t, r.TickUpper, position.TickUpper)
+	assert.Equal(t, OneEther, position.Liquidity)
+
+	position, _, err = FromAmountsWithPrices(pool, lower, upper, RoundOutward, big.NewInt(1800e6), OneEther, false)
+	assert.NoError(t, err)
+	expected, _ := FromAmounts(pool, r.TickLower, r.TickUpper, big.NewInt(1800e6), OneEther, false)
+	assert.Equal(t, expected.Liquidity, position.Liquidity)
+
+	position, _, err = FromAmount0WithPrices(pool, lower, upper, RoundOutward, big.NewInt(1800e6), true)
+	assert.NoError(t, err)
+	expected, _ = FromAmount0(pool, r.TickLower, r.TickUpper, big.NewInt(1800e6), true)
+	assert.Equal(t, expected.Liquidity, position.Liquidity)
+
+	position, _, err = FromAmount1WithPrices(pool, lower, upper, RoundOutward, OneEther)
+	assert.NoError(t, err)
+	expected, _ = FromAmount1(pool, r.TickLower, r.TickUpper, OneEther)
+	assert.Equal(t, expected.Liquidity, position.Liquidity)
+
+	_, _, err = NewPositionFromPrices(pool, OneEther, upper, lower, RoundOutward)
+	assert.ErrorIs(t, err, ErrInvalidPriceRange)
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+)
+
+var ErrInvalidQuoteAmount = errors.New("quote amount must be greater than zero")
+
+// QuotePoint is the result of quoting a single size along a route
+type QuotePoint struct {
+	InputAmount    *entities.CurrencyAmount // The amount in, specified for exact input or quoted for exact output
+	OutputAmount   *entities.CurrencyAmount // The amount out, quoted for exact input or specified for exact output
+	ExecutionPrice *entities.Price          // The price expressed in terms of output amount/input amount
+	PriceImpact    *entities.Percent        // The percent difference between the route's mid price and the execution price
+}
+
+/**
+ * Quotes many sizes along the route in one pass. Each pool simulates the largest size once, and the smaller sizes
+ * resume from the last tick boundary they fully cross instead of swapping from the current price again.
+ * The results are identical to simulating each size with `FromRoute`.
+ * @param amounts the raw amounts to quote, of the input currency for exact input or of the output currency for exact output
+ * @param tradeType whether the amounts are exact inputs or exact outputs
+ * @returns The quotes, in the order of the amounts
+ */
+func (r *Route) QuoteCurve(amounts []*big.Int, tradeType entities.TradeType) ([]*QuotePoint, error) {
+	for _, amount := range amounts {
+		if amount.Sign() <= 0 {
+			return nil, ErrInvalidQuoteAmount
+		}
+	}
+	if len(amounts) == 0 {
+		return nil, nil
+	}
+
+	current := amounts
+	if tradeType == entities.ExactInput {
+		for i, pool := range r.Pools {
+			zeroForOne := r.TokenPath[i].Equal(pool.Token0)
+			calculated, err := pool.swapMany(zeroForOne, current)
+			if err != nil {
+				return nil, err
+			}
+			outputs := make([]*big.Int, len(calculated))
+			for j, c := range calculated {
+				outputs[j] = new(big.Int).Neg(c)
+			}
+			current = outputs
+		}
+	} else {
+		for i := len(r.Pools) - 1; i >= 0; i-- {
+			pool := r.Pools[i]
+			zeroForOne := r.TokenPath[i+1].Equal(pool.Token1)
+			specified := make([]*big.Int, len(current))
+			for j, c := range current {
+				specified[j] = new(big.Int).Neg(c)
+			}
+			inputs, err := pool.swapMany(zeroForOne, specified)
+			if err != nil {
+				return nil, err
+			}
+			current = inputs
+		}
+	}
+
+	midPrice, err := r.MidPrice()
+	if err != nil {
+		return nil, err
+	}
+	points := make([]*QuotePoint, len(amounts))
+	for i := range amounts {
+		var inputAmount, outputAmount *entities.CurrencyAmount
+		if tradeType == entities.ExactInput {
+			inputAmount = entities.FromRawAmount(r.Input, amounts[i])
+			outputAmount = entities.FromRawAmount(r.Output, current[i])
+		} else {
+			inputAmount = entities.FromRawAmount(r.Input, current[i])
+			outputAmount = entities.FromRawAmount(r.Output, amounts[i])
+		}
+
+		spotOutputAmount, err := midPrice.Quote(inputAmount)
+		if err != nil {
+			return nil, err
+		}
+		var priceImpact *entities.Percent
+		if spotOutputAmount.Numerator.Sign() > 0 {
+			impact := spotOutputAmount.Subtract(outputAmount).Divide(spotOutputAmount.Fraction)
+			priceImpact = entities.NewPercent(impact.Numerator, impact.Denominator)
+		} else {
+			priceImpact = entities.NewPercent(constants.Zero, constants.One)
+		}
+
+		points[i] = &QuotePoint{
+			InputAmount:    inputAmount,
+			OutputAmount:   outputAmount,
+			ExecutionPrice: entities.NewPrice(inputAmount.Currency, outputAmount.Currency, inputAmount.Quotient(), outputAmount.Quotient()),
+			PriceImpact:    priceImpact,
+		}
+	}
+	return points, nil
+}
+
+/**
+ * Executes swaps of several amounts from the current pool state, sharing the tick traversal of the largest swap
+ * @param zeroForOne Whether the amount in is token0 or token1
+ * @param amountsSpecified The amounts of the swaps, all exact input (positive) or all exact output (negative)
+ * @returns The amountCalculated of each swap, in the order of the amounts
+ */
+func (p *Pool) swapMany(zeroForOne bool, amountsSpecified []*big.Int) ([]*big.Int, error) {
+	sqrtPriceLimitX96, err := p.checkedSqrtPriceLimit(zeroForOne, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	largest := amountsSpecified[0]
+	for _, amount := range amountsSpecified[1:] {
+		if amount.CmpAbs(largest) > 0 {
+			largest = amount
+		}
+	}
+
+	// simulate the largest swap, keeping the state at the start of every step along with the amount consumed so far
+	var (
+		snapshots []swapState
+		consumed  []*big.Int
+	)
+	state := p.initialSwapState(largest)
+	err = p.swapSteps(zeroForOne, state, sqrtPriceLimitX96, func(s swapState) {
+		snapshots = append(snapshots, s)
+		consumed = append(consumed, new(big.Int).Sub(largest, s.amountSpecifiedRemaining))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := state.checkFilled(largest, false); err != nil {
+		return nil, err
+	}
+
+	calculated := make([]*big.Int, len(amountsSpecified))
+	for i, amount := range amountsSpecified {
+		if amount.Cmp(largest) == 0 {
+			calculated[i] = state.amountCalculated
+			continue
+		}
+		// resume from the last step that the smaller amount also reaches; every step before it was fully taken
+		k := sort.Search(len(consumed), func(k int) bool {
+			return consumed[k].CmpAbs(amount) > 0
+		}) - 1
+		resumed := snapshots[k]
+		resumed.amountSpecifiedRemaining = new(big.Int).Sub(amount, consumed[k])
+		if err := p.swapSteps(zeroForOne, &resumed, sqrtPriceLimitX96, nil); err != nil {
+			return nil, err
+		}
+		calculated[i] = resumed.amountCalculated
+	}
+	return calculated, nil
+}
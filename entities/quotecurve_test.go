@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// a pool with liquidity concentrated in several ranges around the current price
+func multiRangePool(token0, token1 *entities.Token) *Pool {
+	ticks := []Tick{
+		{Index: -1200, LiquidityNet: big.NewInt(500000), LiquidityGross: big.NewInt(500000)},
+		{Index: -120, LiquidityNet: big.NewInt(2000000), LiquidityGross: big.NewInt(2000000)},
+		{Index: 60, LiquidityNet: big.NewInt(-1000000), LiquidityGross: big.NewInt(1000000)},
+		{Index: 300, LiquidityNet: big.NewInt(-1000000), LiquidityGross: big.NewInt(1000000)},
+		{Index: 1200, LiquidityNet: big.NewInt(-500000), LiquidityGross: big.NewInt(500000)},
+	}
+	p, err := NewTickListDataProvider(ticks, constants.TickSpacings[constants.FeeMedium])
+	if err != nil {
+		panic(err)
+	}
+	pool, err := NewPool(token0, token1, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(2500000), 0, p)
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestQuoteCurve(t *testing.T) {
+	pool_0_1_ranges := multiRangePool(token0, token1)
+	amounts := []*big.Int{big.NewInt(20000), big.NewInt(10), big.NewInt(1000), big.NewInt(10000), big.NewInt(15000), big.NewInt(100), big.NewInt(10000)}
+
+	routes := []*Route{}
+	for _, pools := range [][]*Pool{{pool_0_1_ranges}, {pool_0_1_ranges, pool_1_2}, {pool_0_2, pool_1_2, pool_0_1_ranges}} {
+		r, err := NewRoute(pools, token0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		routes = append(routes, r)
+	}
+	r, err := NewRoute([]*Pool{pool_0_1_ranges}, token1, token0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes = append(routes, r)
+
+	for _, route := range routes {
+		// matches simulating every exact input size on its own
+		points, err := route.QuoteCurve(amounts, entities.ExactInput)
+		assert.NoError(t, err)
+		assert.Len(t, points, len(amounts))
+		for i, amount := range amounts {
+			trade, err := FromRoute(route, entities.FromRawAmount(route.Input, amount), entities.ExactInput)
+			assert.NoError(t, err)
+			assert.Equal(t, amount, points[i].InputAmount.Quotient())
+			assert.Equal(t, trade.OutputAmount().Quotient(), points[i].OutputAmount.Quotient())
+			priceImpact, err := trade.PriceImpact()
+			assert.NoError(t, err)
+			assert.True(t, priceImpact.EqualTo(points[i].PriceImpact.Fraction))
+			assert.True(t, points[i].ExecutionPrice.EqualTo(entities.NewFraction(trade.OutputAmount().Quotient(), amount)))
+		}
+
+		// matches simulating every exact output size on its own
+		points, err = route.QuoteCurve(amounts[1:5], entities.ExactOutput)
+		assert.NoError(t, err)
+		for i, amount := range amounts[1:5] {
+			trade, err := FromRoute(route, entities.FromRawAmount(route.Output, amount), entities.ExactOutput)
+			assert.NoError(t, err)
+			assert.Equal(t, amount, points[i].OutputAmount.Quotient())
+			assert.Equal(t, trade.InputAmount().Quotient(), points[i].InputAmount.Quotient())
+		}
+	}
+
+	// price impact grows with size
+	points, err := routes[0].QuoteCurve([]*big.Int{big.NewInt(1000), big.NewInt(10000), big.NewInt(20000)}, entities.ExactInput)
+	assert.NoError(t, err)
+	assert.True(t, points[0].PriceImpact.LessThan(points[1].PriceImpact.Fraction))
+	assert.True(t, points[1].PriceImpact.LessThan(points[2].PriceImpact.Fraction))
+
+	_, err = routes[0].QuoteCurve([]*big.Int{big.NewInt(0)}, entities.ExactInput)
+	assert.ErrorIs(t, err, ErrInvalidQuoteAmount)
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var ErrRangeOrderPoolMismatch = errors.New("pool does not match range order")
+
+// RangeOrder is a limit order made of a single sided position one tick spacing wide, which the pool fills by
+// converting the sell token into the other token as the price crosses the range
+type RangeOrder struct {
+	Position   *Position       // The position holding the order, in the pool it was placed in
+	SellToken  *entities.Token // The token being sold
+	BuyToken   *entities.Token // The token being bought
+	PriceLower *entities.Price // The price of the sell token at the start of the range, the worst the order fills at
+	PriceUpper *entities.Price // The price of the sell token at the end of the range, where the order is fully filled
+
+	amountIn *entities.CurrencyAmount
+}
+
+/**
+ * Places a range order in the narrowest range at or beyond a target price, on the side of the current tick where
+ * the position only holds the sell token. A target price on the wrong side of the current price is moved to the
+ * first range beyond the current tick.
+ * @param pool The pool to place the order in
+ * @param sellToken The token to sell
+ * @param amount The raw amount of the sell token
+ * @param targetPrice The lowest price of the sell token, in terms of the other token, to sell at
+ * @returns The range order
+ */
+func NewRangeOrder(pool *Pool, sellToken *entities.Token, amount *big.Int, targetPrice *entities.Price) (*RangeOrder, error) {
+	if !pool.InvolvesToken(sellToken) {
+		return nil, ErrTokenNotInvolved
+	}
+	buyToken := pool.Token1
+	if sellToken.Equal(pool.Token1) {
+		buyToken = pool.Token0
+	}
+	if !targetPrice.BaseCurrency.Equal(sellToken) || !targetPrice.QuoteCurrency.Equal(buyToken) {
+		return nil, ErrInvalidPrice
+	}
+	if amount.Sign() <= 0 {
+		return nil, ErrZeroAmounts
+	}
+
+	tickSpacing := pool.tickSpacing()
+	var tickLower int
+	if sellToken.Equal(pool.Token0) {
+		// selling token0 fills as the tick rises, so the range starts at or above the target and above the current tick
+		var err error
+		tickLower, err = snapPrice(pool, targetPrice, sellToken, buyToken, RoundInward, false)
+		if err != nil {
+			return nil, err
+		}
+		if minLower := floorToSpacing(pool.TickCurrent, tickSpacing) + tickSpacing; tickLower < minLower {
+			tickLower = minLower
+		}
+	} else {
+		// selling token1 fills as the tick falls, so the range ends at or below the target and at or below the current tick
+		tickUpper, err := snapPrice(pool, targetPrice, sellToken, buyToken, RoundInward, true)
+		if err != nil {
+			return nil, err
+		}
+		if maxUpper := floorToSpacing(pool.TickCurrent, tickSpacing); tickUpper > maxUpper {
+			tickUpper = maxUpper
+		}
+		tickLower = tickUpper - tickSpacing
+	}
+	tickUpper := tickLower + tickSpacing
+
+	var (
+		position *Position
+		err      error
+	)
+	if sellToken.Equal(pool.Token0) {
+		position, err = FromAmount0(pool, tickLower, tickUpper, amount, true)
+	} else {
+		position, err = FromAmount1(pool, tickLower, tickUpper, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if position.Liquidity.Sign() <= 0 {
+		return nil, ErrZeroAmounts
+	}
+
+	priceLower, err := utils.TickToPrice(sellToken, buyToken, tickLower)
+	if err != nil {
+		return nil, err
+	}
+	priceUpper, err := utils.TickToPrice(sellToken, buyToken, tickUpper)
+	if err != nil {
+		return nil, err
+	}
+	if sellToken.Equal(pool.Token1) {
+		priceLower, priceUpper = priceUpper, priceLower
+	}
+	order := &RangeOrder{
+		Position:   position,
+		SellToken:  sellToken,
+		BuyToken:   buyToken,
+		PriceLower: priceLower,
+		PriceUpper: priceUpper,
+	}
+	if order.amountIn, err = order.remaining(pool); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// AmountIn returns the amount of the sell token the order holds before any of it is filled
+func (o *RangeOrder) AmountIn() *entities.CurrencyAmount {
+	return o.amountIn
+}
+
+// AmountOut returns the amount of the buy token the order is withdrawn for once fully filled
+func (o *RangeOrder) AmountOut() (*entities.CurrencyAmount, error) {
+	sqrtRatioX96, err := utils.GetSqrtRatioAtTick(o.Position.TickUpper)
+	if o.SellToken.Equal(o.Position.Pool.Token1) {
+		sqrtRatioX96, err = utils.GetSqrtRatioAtTick(o.Position.TickLower)
+	}
+	if err != nil {
+		return nil, err
+	}
+	amount0, amount1, err := o.Position.AmountsAtPrice(sqrtRatioX96)
+	if err != nil {
+		return nil, err
+	}
+	if o.SellToken.Equal(o.Position.Pool.Token0) {
+		return amount1, nil
+	}
+	return amount0, nil
+}
+
+/**
+ * Returns the share of the order that is filled at a pool state
+ * @param pool The pool the order was placed in, at the state to check
+ * @returns The percentage of the sell token that has been converted
+ */
+func (o *RangeOrder) FillPercentage(pool *Pool) (*entities.Percent, error) {
+	remaining, err := o.remaining(pool)
+	if err != nil {
+		return nil, err
+	}
+	sold := o.amountIn.Subtract(remaining)
+	filled := sold.Divide(o.amountIn.Fraction)
+	return entities.NewPercent(filled.Numerator, filled.Denominator), nil
+}
+
+/**
+ * Returns whether the price has crossed the whole range of the order at a pool state, so that the position only
+ * holds the buy token and is ready to withdraw
+ * @param pool The pool the order was placed in, at the state to check
+ */
+func (o *RangeOrder) IsFilled(pool *Pool) (bool, error) {
+	remaining, err := o.remaining(pool)
+	if err != nil {
+		return false, err
+	}
+	return remaining.Quotient().Sign() == 0, nil
+}
+
+// remaining returns the amount of the sell token left in the order at a pool state
+func (o *RangeOrder) remaining(pool *Pool) (*entities.CurrencyAmount, error) {
+	if !pool.Token0.Equal(o.Position.Pool.Token0) || !pool.Token1.Equal(o.Position.Pool.Token1) || pool.Fee != o.Position.Pool.Fee {
+		return nil, ErrRangeOrderPoolMismatch
+	}
+	amount0, amount1, err := o.Position.AmountsAtPrice(pool.SqrtRatioX96)
+	if err != nil {
+		return nil, err
+	}
+	if o.SellToken.Equal(pool.Token0) {
+		return amount0, nil
+	}
+	return amount1, nil
+}
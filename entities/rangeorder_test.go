@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// poolAtTick returns the pool of token0 and token1 with the price at a tick
+func poolAtTick(t *testing.T, tick int) *Pool {
+	sqrtRatioX96, err := utils.GetSqrtRatioAtTick(tick)
+	assert.NoError(t, err)
+	pool, err := NewPool(token0, token1, constants.FeeMedium, sqrtRatioX96, big.NewInt(0), tick, nil)
+	assert.NoError(t, err)
+	return pool
+}
+
+func TestNewRangeOrder(t *testing.T) {
+	pool := poolAtTick(t, 0)
+	amount := OneEther
+
+	// sells token0 in the range starting at or above the target price
+	target := entities.NewPrice(token0, token1, big.NewInt(100), big.NewInt(105))
+	order, err := NewRangeOrder(pool, token0, amount, target)
+	assert.NoError(t, err)
+	assert.Equal(t, 540, order.Position.TickLower)
+	assert.Equal(t, 600, order.Position.TickUpper)
+	assert.True(t, order.BuyToken.Equal(token1))
+	assert.False(t, order.PriceLower.LessThan(target.Fraction))
+	assert.True(t, order.PriceUpper.GreaterThan(order.PriceLower.Fraction))
+	assert.True(t, order.AmountIn().Currency.Equal(token0))
+	assert.False(t, order.AmountIn().GreaterThan(entities.NewFraction(amount, constants.One)))
+	amountOut, err := order.AmountOut()
+	assert.NoError(t, err)
+	assert.True(t, amountOut.Currency.Equal(token1))
+	worst, err := order.PriceLower.Quote(order.AmountIn())
+	assert.NoError(t, err)
+	assert.True(t, amountOut.GreaterThan(worst.Fraction), "fills above the lower price")
+
+	// sells token1 in the range ending at or below the target price of token1
+	target = entities.NewPrice(token1, token0, big.NewInt(100), big.NewInt(105))
+	order, err = NewRangeOrder(pool, token1, amount, target)
+	assert.NoError(t, err)
+	assert.Equal(t, -600, order.Position.TickLower)
+	assert.Equal(t, -540, order.Position.TickUpper)
+	assert.False(t, order.PriceLower.LessThan(target.Fraction))
+	assert.True(t, order.PriceUpper.GreaterThan(order.PriceLower.Fraction))
+	amountOut, err = order.AmountOut()
+	assert.NoError(t, err)
+	assert.True(t, amountOut.Currency.Equal(token0))
+
+	// moves a target on the wrong side of the price to the first range beyond the current tick
+	order, err = NewRangeOrder(poolAtTick(t, 30), token0, amount, entities.NewPrice(token0, token1, big.NewInt(10), big.NewInt(9)))
+	assert.NoError(t, err)
+	assert.Equal(t, 60, order.Position.TickLower)
+	order, err = NewRangeOrder(poolAtTick(t, 30), token1, amount, entities.NewPrice(token1, token0, big.NewInt(10), big.NewInt(9)))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, order.Position.TickUpper)
+
+	_, err = NewRangeOrder(pool, token2, amount, target)
+	assert.ErrorIs(t, err, ErrTokenNotInvolved)
+	_, err = NewRangeOrder(pool, token0, amount, target)
+	assert.ErrorIs(t, err, ErrInvalidPrice)
+	_, err = NewRangeOrder(pool, token1, big.NewInt(0), target)
+	assert.ErrorIs(t, err, ErrZeroAmounts)
+}
+
+func TestRangeOrderFill(t *testing.T) {
+	target := entities.NewPrice(token0, token1, big.NewInt(100), big.NewInt(105))
+	order, err := NewRangeOrder(poolAtTick(t, 0), token0, OneEther, target)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		tick   int
+		filled bool
+	}{{0, false}, {540, false}, {570, false}, {599, false}, {600, true}, {1200, true}} {
+		pool := poolAtTick(t, test.tick)
+		fill, err := order.FillPercentage(pool)
+		assert.NoError(t, err)
+		filled, err := order.IsFilled(pool)
+		assert.NoError(t, err)
+		assert.Equal(t, test.filled, filled)
+		switch {
+		case test.tick <= 540:
+			assert.Equal(t, 0, fill.Numerator.Sign())
+		case test.filled:
+			assert.True(t, fill.EqualTo(entities.NewFraction(constants.One, constants.One)))
+		default:
+			assert.True(t, fill.GreaterThan(constants.PercentZero))
+			assert.True(t, fill.LessThan(entities.NewFraction(constants.One, constants.One)))
+		}
+	}
+
+	// fills token1 orders as the price falls
+	order, err = NewRangeOrder(poolAtTick(t, 0), token1, OneEther, entities.NewPrice(token1, token0, big.NewInt(100), big.NewInt(105)))
+	assert.NoError(t, err)
+	filled, err := order.IsFilled(poolAtTick(t, -570))
+	assert.NoError(t, err)
+	assert.False(t, filled)
+	filled, err = order.IsFilled(poolAtTick(t, -600))
+	assert.NoError(t, err)
+	assert.True(t, filled)
+
+	other, err := NewPool(token0, token2, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), big.NewInt(0), 0, nil)
+	assert.NoError(t, err)
+	_, err = order.FillPercentage(other)
+	assert.ErrorIs(t, err, ErrRangeOrderPoolMismatch)
+}
@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var ErrZeroAmounts = errors.New("zero amounts")
+
+/**
+ * Computes the swap that best fits a pair of balances to a tick range before minting, e.g. to add liquidity with only
+ * one of the tokens. The swap is simulated against the pool, and the position is minted at the pool price after
+ * the swap, so the leftover balances are as small as the pool allows.
+ * @param pool The pool to swap through and mint in
+ * @param tickLower The lower tick of the position
+ * @param tickUpper The upper tick of the position
+ * @param amount0 The token0 balance
+ * @param amount1 The token1 balance
+ * @returns The exact input trade that rebalances the tokens, or nil if no swap is needed
+ * @returns The position that can be minted with the balances after the swap, in the pool as it is after the swap
+ */
+func SwapToRatio(pool *Pool, tickLower, tickUpper int, amount0, amount1 *big.Int) (*Trade, *Position, error) {
+	if _, err := NewPosition(pool, constants.Zero, tickLower, tickUpper); err != nil {
+		return nil, nil, err
+	}
+	if amount0.Sign() <= 0 && amount1.Sign() <= 0 {
+		return nil, nil, ErrZeroAmounts
+	}
+	sqrtRatioAX96, err := utils.GetSqrtRatioAtTick(tickLower)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtRatioBX96, err := utils.GetSqrtRatioAtTick(tickUpper)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// swap whichever token is in excess at the current price
+	zeroForOne := hasExcessToken0(pool.SqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, amount0, amount1)
+	tokenIn, tokenOut, balanceIn := pool.Token1, pool.Token0, amount1
+	if zeroForOne {
+		tokenIn, tokenOut, balanceIn = pool.Token0, pool.Token1, amount0
+	}
+
+	// simulates swapping amountIn, returning the pool after the swap and the balances left to mint with
+	simulate := func(amountIn *big.Int) (*Pool, *big.Int, *big.Int, bool) {
+		if amountIn.Sign() == 0 {
+			return pool, amount0, amount1, true
+		}
+		amountOut, poolAfter, err := pool.GetOutputAmount(entities.FromRawAmount(tokenIn, amountIn), nil)
+		if err != nil {
+			return nil, nil, nil, false
+		}
+		if zeroForOne {
+			return poolAfter, new(big.Int).Sub(amount0, amountIn), new(big.Int).Add(amount1, amountOut.Quotient()), true
+		}
+		return poolAfter, new(big.Int).Add(amount0, amountOut.Quotient()), new(big.Int).Sub(amount1, amountIn), true
+	}
+	// whether the token being sold is still in excess after swapping amountIn
+	stillInExcess := func(amountIn *big.Int) bool {
+		poolAfter, a0, a1, ok := simulate(amountIn)
+		if !ok {
+			return false
+		}
+		return hasExcessToken0(poolAfter.SqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, a0, a1) == zeroForOne
+	}
+
+	// binary search for the largest swap after which the token sold is still in excess
+	lo, hi := big.NewInt(0), new(big.Int).Set(balanceIn)
+	if stillInExcess(hi) {
+		lo = hi
+	}
+	for new(big.Int).Sub(hi, lo).Cmp(constants.One) > 0 {
+		mid := new(big.Int).Rsh(new(big.Int).Add(lo, hi), 1)
+		if stillInExcess(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	// the best fit is either side of the crossover
+	var (
+		bestAmountIn *big.Int
+		bestPosition *Position
+	)
+	for _, amountIn := range []*big.Int{lo, hi} {
+		poolAfter, a0, a1, ok := simulate(amountIn)
+		if !ok {
+			continue
+		}
+		position, err := FromAmounts(poolAfter, tickLower, tickUpper, a0, a1, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if bestPosition == nil || position.Liquidity.Cmp(bestPosition.Liquidity) > 0 {
+			bestAmountIn, bestPosition = amountIn, position
+		}
+	}
+	if bestPosition == nil {
+		return nil, nil, ErrInsufficientReserves
+	}
+	if bestAmountIn.Sign() == 0 {
+		return nil, bestPosition, nil
+	}
+
+	route, err := NewRoute([]*Pool{pool}, tokenIn, tokenOut)
+	if err != nil {
+		return nil, nil, err
+	}
+	trade, err := FromRoute(route, entities.FromRawAmount(tokenIn, bestAmountIn), entities.ExactInput)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trade, bestPosition, nil
+}
+
+// hasExcessToken0 returns whether amount0 can back more liquidity in the range than amount1 at the given price
+func hasExcessToken0(sqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, amount0, amount1 *big.Int) bool {
+	liquidity0 := utils.MaxLiquidityForAmounts(sqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, amount0, entities.MaxUint256, true)
+	liquidity1 := utils.MaxLiquidityForAmounts(sqrtRatioX96, sqrtRatioAX96, sqrtRatioBX96, entities.MaxUint256, amount1, true)
+	return liquidity0.Cmp(liquidity1) > 0
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwapToRatio(t *testing.T) {
+	pool := multiRangePool(token0, token1)
+
+	_, _, err := SwapToRatio(pool, 120, -120, big.NewInt(1000), big.NewInt(0))
+	assert.ErrorIs(t, err, ErrTickOrder, "throws for an invalid range")
+
+	_, _, err = SwapToRatio(pool, -120, 120, big.NewInt(0), big.NewInt(0))
+	assert.ErrorIs(t, err, ErrZeroAmounts, "throws without amounts")
+
+	// leftover balances after minting, given the trade and the position
+	leftovers := func(trade *Trade, position *Position, amount0, amount1 *big.Int) (*big.Int, *big.Int) {
+		a0, a1 := new(big.Int).Set(amount0), new(big.Int).Set(amount1)
+		if trade != nil {
+			if trade.InputAmount().Currency.Equal(token0) {
+				a0.Sub(a0, trade.InputAmount().Quotient())
+				a1.Add(a1, trade.OutputAmount().Quotient())
+			} else {
+				a1.Sub(a1, trade.InputAmount().Quotient())
+				a0.Add(a0, trade.OutputAmount().Quotient())
+			}
+		}
+		mint0, mint1, err := position.MintAmounts()
+		assert.NoError(t, err)
+		return a0.Sub(a0, mint0), a1.Sub(a1, mint1)
+	}
+
+	// swaps part of token0 when only token0 is held
+	trade, position, err := SwapToRatio(pool, -600, 600, big.NewInt(10000), big.NewInt(0))
+	assert.NoError(t, err)
+	assert.True(t, trade.InputAmount().Currency.Equal(token0))
+	assert.True(t, trade.InputAmount().GreaterThan(entities.NewFraction(big.NewInt(4000), big.NewInt(1))))
+	assert.True(t, trade.InputAmount().LessThan(entities.NewFraction(big.NewInt(6000), big.NewInt(1))))
+	left0, left1 := leftovers(trade, position, big.NewInt(10000), big.NewInt(0))
+	assert.True(t, left0.Sign() >= 0 && left1.Sign() >= 0, "mints within the balances")
+	assert.True(t, left0.Cmp(big.NewInt(10)) <= 0 && left1.Cmp(big.NewInt(10)) <= 0, "leaves only dust")
+	assert.Equal(t, pool, trade.Swaps[0].Route.Pools[0], "the trade is routed through the pool before the swap")
+	assert.True(t, position.Pool.SqrtRatioX96.Cmp(pool.SqrtRatioX96) < 0, "the position is minted at the price after the swap")
+
+	// swaps part of token1 from an unbalanced mix
+	trade, position, err = SwapToRatio(pool, -600, 600, big.NewInt(1000), big.NewInt(9000))
+	assert.NoError(t, err)
+	assert.True(t, trade.InputAmount().Currency.Equal(token1))
+	left0, left1 = leftovers(trade, position, big.NewInt(1000), big.NewInt(9000))
+	assert.True(t, left0.Sign() >= 0 && left1.Sign() >= 0, "mints within the balances")
+	assert.True(t, left0.Cmp(big.NewInt(10)) <= 0 && left1.Cmp(big.NewInt(10)) <= 0, "leaves only dust")
+
+	// swaps everything into token0 for a range above the price
+	trade, position, err = SwapToRatio(pool, 600, 1200, big.NewInt(0), big.NewInt(5000))
+	assert.NoError(t, err)
+	assert.True(t, trade.InputAmount().EqualTo(entities.NewFraction(big.NewInt(5000), big.NewInt(1))))
+	_, mint1, err := position.MintAmounts()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mint1.Sign())
+
+	// does not swap when the balances already fit
+	trade, position, err = SwapToRatio(pool, 600, 1200, big.NewInt(5000), big.NewInt(0))
+	assert.NoError(t, err)
+	assert.Nil(t, trade)
+	assert.Equal(t, pool, position.Pool)
+	expected, err := FromAmounts(pool, 600, 1200, big.NewInt(5000), big.NewInt(0), false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Liquidity, position.Liquidity)
+}
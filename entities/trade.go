@@ -72,7 +72,8 @@ func tradeComparator(a, b *Trade) int {
  * Represents a trade executed against a set of routes where some percentage of the input is
  * split across each route.
  *
- * Each route has its own set of pools. Pools can not be re-used across routes.
+ * Each route has its own set of pools. Pools can not be re-used across routes, unless the trade is constructed with
+ * `FromRoutesSequential`.
  *
  * Does not account for slippage, i.e., changes in price environment that can occur between
  * the time the trade is submitted and when it is executed.
@@ -267,51 +268,116 @@ type WrappedRoute struct {
 func FromRoutes(wrappedRoutes []*WrappedRoute, tradeType entities.TradeType) (*Trade, error) {
 	var swaps []*Swap
 	for _, wrappedRoute := range wrappedRoutes {
-		amounts := make([]*entities.CurrencyAmount, len(wrappedRoute.Route.TokenPath))
-		var (
-			inputAmount  *entities.CurrencyAmount
-			outputAmount *entities.CurrencyAmount
-		)
-		amount := wrappedRoute.Amount
-		route := wrappedRoute.Route
-		if tradeType == entities.ExactInput {
-			if !amount.Currency.Wrapped().Equal(route.Input.Wrapped()) {
-				return nil, ErrInvalidAmountForRoute
+		swap, err := simulateWrappedRoute(wrappedRoute, tradeType, nil)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+	return newTrade(swaps, tradeType)
+}
+
+/**
+ * Constructs a trade from routes by simulating swaps in order against a shared working copy of the pool state,
+ * so that routes going through the same pool see the price impact of the routes before them.
+ * Unlike `FromRoutes`, pools may be re-used across routes.
+ *
+ * @param routes the routes to swap through, in execution order, and how much of the amount should be routed through each
+ * @param tradeType whether the trade is an exact input or exact output swap
+ * @returns The trade
+ */
+func FromRoutesSequential(wrappedRoutes []*WrappedRoute, tradeType entities.TradeType) (*Trade, error) {
+	var swaps []*Swap
+	poolStates := make(map[poolKey]*Pool)
+	for _, wrappedRoute := range wrappedRoutes {
+		swap, err := simulateWrappedRoute(wrappedRoute, tradeType, poolStates)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+	if err := validateTradeCurrencies(swaps); err != nil {
+		return nil, err
+	}
+	return &Trade{
+		Swaps:     swaps,
+		TradeType: tradeType,
+	}, nil
+}
+
+/**
+ * Simulates swapping the amount of a wrapped route through its pools
+ * @param wrappedRoute the route and the amount specified, either input or output, depending on tradeType
+ * @param tradeType whether the trade is an exact input or exact output swap
+ * @param poolStates if not nil, the working pool state keyed by the pools' tokens and fee; it is read instead of the route's pools
+ * and updated after every swap
+ * @returns The swap
+ */
+func simulateWrappedRoute(wrappedRoute *WrappedRoute, tradeType entities.TradeType, poolStates map[poolKey]*Pool) (*Swap, error) {
+	amounts := make([]*entities.CurrencyAmount, len(wrappedRoute.Route.TokenPath))
+	var (
+		inputAmount  *entities.CurrencyAmount
+		outputAmount *entities.CurrencyAmount
+	)
+	amount := wrappedRoute.Amount
+	route := wrappedRoute.Route
+	if tradeType == entities.ExactInput {
+		if !amount.Currency.Wrapped().Equal(route.Input.Wrapped()) {
+			return nil, ErrInvalidAmountForRoute
+		}
+		amounts[0] = entities.FromFractionalAmount(route.Input.Wrapped(), amount.Numerator, amount.Denominator)
+		for i := 0; i < len(route.TokenPath)-1; i++ {
+			pool, key := currentPoolState(route.Pools[i], poolStates)
+			outputAmount, nextPool, err := pool.GetOutputAmount(amounts[i], nil)
+			if err != nil {
+				return nil, err
 			}
-			amounts[0] = entities.FromFractionalAmount(route.Input.Wrapped(), amount.Numerator, amount.Denominator)
-			for i := 0; i < len(route.TokenPath)-1; i++ {
-				pool := route.Pools[i]
-				outputAmount, _, err := pool.GetOutputAmount(amounts[i], nil)
-				if err != nil {
-					return nil, err
-				}
-				amounts[i+1] = outputAmount
+			if poolStates != nil {
+				poolStates[key] = nextPool
 			}
-			inputAmount = entities.FromFractionalAmount(route.Input, amount.Numerator, amount.Denominator)
-			outputAmount = entities.FromFractionalAmount(route.Output, amounts[len(amounts)-1].Numerator, amounts[len(amounts)-1].Denominator)
-		} else {
-			if !amount.Currency.Wrapped().Equal(route.Output.Wrapped()) {
-				return nil, ErrInvalidAmountForRoute
+			amounts[i+1] = outputAmount
+		}
+		inputAmount = entities.FromFractionalAmount(route.Input, amount.Numerator, amount.Denominator)
+		outputAmount = entities.FromFractionalAmount(route.Output, amounts[len(amounts)-1].Numerator, amounts[len(amounts)-1].Denominator)
+	} else {
+		if !amount.Currency.Wrapped().Equal(route.Output.Wrapped()) {
+			return nil, ErrInvalidAmountForRoute
+		}
+		amounts[len(amounts)-1] = entities.FromFractionalAmount(route.Output.Wrapped(), amount.Numerator, amount.Denominator)
+		for i := len(route.TokenPath) - 1; i > 0; i-- {
+			pool, key := currentPoolState(route.Pools[i-1], poolStates)
+			inputAmount, nextPool, err := pool.GetInputAmount(amounts[i], nil)
+			if err != nil {
+				return nil, err
 			}
-			amounts[len(amounts)-1] = entities.FromFractionalAmount(route.Output.Wrapped(), amount.Numerator, amount.Denominator)
-			for i := len(route.TokenPath) - 1; i > 0; i-- {
-				pool := route.Pools[i-1]
-				inputAmount, _, err := pool.GetInputAmount(amounts[i], nil)
-				if err != nil {
-					return nil, err
-				}
-				amounts[i-1] = inputAmount
+			if poolStates != nil {
+				poolStates[key] = nextPool
 			}
-			inputAmount = entities.FromFractionalAmount(route.Input, amounts[0].Numerator, amounts[0].Denominator)
-			outputAmount = entities.FromFractionalAmount(route.Output, amount.Numerator, amount.Denominator)
+			amounts[i-1] = inputAmount
 		}
-		swaps = append(swaps, &Swap{
-			Route:        route,
-			InputAmount:  inputAmount,
-			OutputAmount: outputAmount})
+		inputAmount = entities.FromFractionalAmount(route.Input, amounts[0].Numerator, amounts[0].Denominator)
+		outputAmount = entities.FromFractionalAmount(route.Output, amount.Numerator, amount.Denominator)
+	}
+	return &Swap{
+		Route:        route,
+		InputAmount:  inputAmount,
+		OutputAmount: outputAmount}, nil
+}
 
+// poolKey identifies a pool by its tokens and fee, which is unique among the pools of a factory
+type poolKey struct {
+	token0 common.Address
+	token1 common.Address
+	fee    constants.FeeAmount
+}
+
+// currentPoolState returns the working state of the pool and its key, or the pool itself if there is no working state
+func currentPoolState(pool *Pool, poolStates map[poolKey]*Pool) (*Pool, poolKey) {
+	key := poolKey{token0: pool.Token0.Address, token1: pool.Token1.Address, fee: pool.Fee}
+	if state, ok := poolStates[key]; ok {
+		return state, key
 	}
-	return newTrade(swaps, tradeType)
+	return pool, key
 }
 
 /**
@@ -350,15 +416,8 @@ func CreateUncheckedTradeWithMultipleRoutes(routes []*Swap, tradeType entities.T
  * @param tradeType The type of trade, exact input or exact output
  */
 func newTrade(routes []*Swap, tradeType entities.TradeType) (*Trade, error) {
-	inputCurrency := routes[0].InputAmount.Currency
-	outputCurrency := routes[0].OutputAmount.Currency
-	for _, route := range routes {
-		if !inputCurrency.Wrapped().Equal(route.Route.Input.Wrapped()) {
-			return nil, ErrInputCurrencyMismatch
-		}
-		if !outputCurrency.Wrapped().Equal(route.Route.Output.Wrapped()) {
-			return nil, ErrOutputCurrencyMismatch
-		}
+	if err := validateTradeCurrencies(routes); err != nil {
+		return nil, err
 	}
 
 	var numPools int
@@ -387,6 +446,21 @@ func newTrade(routes []*Swap, tradeType entities.TradeType) (*Trade, error) {
 	}, nil
 }
 
+// validateTradeCurrencies checks that all routes share the input and output currency of the first route
+func validateTradeCurrencies(routes []*Swap) error {
+	inputCurrency := routes[0].InputAmount.Currency
+	outputCurrency := routes[0].OutputAmount.Currency
+	for _, route := range routes {
+		if !inputCurrency.Wrapped().Equal(route.Route.Input.Wrapped()) {
+			return ErrInputCurrencyMismatch
+		}
+		if !outputCurrency.Wrapped().Equal(route.Route.Output.Wrapped()) {
+			return ErrOutputCurrencyMismatch
+		}
+	}
+	return nil
+}
+
 /**
  * Get the minimum amount that must be received from this trade for the given slippage tolerance
  * @param slippageTolerance The tolerance of unfavorable slippage from the execution price of this trade
@@ -496,11 +570,10 @@ func BestTradeExactIn(pools []*Pool, currencyAmountIn *entities.CurrencyAmount,
 		}
 		amountOut, _, err := pool.GetOutputAmount(amountIn, nil)
 		if err != nil {
-			// TODO
-			// input too low
-			//  if (error.isInsufficientInputAmountError) {
-			// 	continue
-			//       }
+			// input too low or not enough liquidity in this pool
+			if isInsufficientLiquidityError(err) {
+				continue
+			}
 			return nil, err
 		}
 		// we have arrived at the output token, so this is the final trade of one of the paths
@@ -575,11 +648,10 @@ func BestTradeExactOut(pools []*Pool, currencyIn entities.Currency, currencyAmou
 		}
 		amountIn, _, err := pool.GetInputAmount(amountOut, nil)
 		if err != nil {
-			// TODO
 			// not enough liquidity in this pool
-			// if error.isInsufficientReservesError {
-			// 	continue
-			// }
+			if isInsufficientLiquidityError(err) {
+				continue
+			}
 			return nil, err
 		}
 		// we have arrived at the input token, so this is the final trade of one of the paths
@@ -611,6 +683,11 @@ func BestTradeExactOut(pools []*Pool, currencyIn entities.Currency, currencyAmou
 	return bestTrades, nil
 }
 
+// isInsufficientLiquidityError returns whether a swap failed because the pool cannot fill it, rather than because of bad input
+func isInsufficientLiquidityError(err error) bool {
+	return errors.Is(err, ErrInsufficientInputAmount) || errors.Is(err, ErrInsufficientReserves) || errors.Is(err, ErrPriceLimitReached)
+}
+
 // sortedInsert given an array of items sorted by `comparator`, insert an item into its sort index and constrain the size to
 // `maxSize` by removing the last item
 func sortedInsert(items []*Trade, add *Trade, maxSize int, comparator func(a, b *Trade) int) ([]*Trade, error) {
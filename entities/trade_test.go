@@ -200,6 +200,68 @@ func TestFromRoutes(t *testing.T) {
 	assert.ErrorIs(t, err, ErrDuplicatePools)
 }
 
+func TestFromRoutesSequential(t *testing.T) {
+	// re-used pools see the price impact of earlier routes for exact input
+	r0, _ := NewRoute([]*Pool{pool_0_1, pool_weth_1}, token0, Ether)
+	r1, _ := NewRoute([]*Pool{pool_0_1, pool_1_2, pool_weth_2}, token0, Ether)
+	r2, _ := NewRoute([]*Pool{pool_0_1, pool_1_2, pool_weth_2}, token0, Ether)
+	trade, err := FromRoutesSequential([]*WrappedRoute{
+		{Amount: entities.FromRawAmount(token0, big.NewInt(4500)), Route: r0},
+		{Amount: entities.FromRawAmount(token0, big.NewInt(5500)), Route: r1},
+	}, entities.ExactInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	independent, err := FromRoute(r2, entities.FromRawAmount(token0, big.NewInt(5500)), entities.ExactInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, trade.InputAmount().Currency, token0)
+	assert.Equal(t, trade.OutputAmount().Currency, Ether)
+	assert.True(t, trade.Swaps[1].OutputAmount.LessThan(independent.OutputAmount().Fraction))
+
+	// matches independent quoting when no pool is shared
+	r0, _ = NewRoute([]*Pool{pool_weth_0}, token0, Ether)
+	r1, _ = NewRoute([]*Pool{pool_0_1, pool_weth_1}, token0, Ether)
+	wrappedRoutes := []*WrappedRoute{
+		{Amount: entities.FromRawAmount(token0, big.NewInt(3000)), Route: r0},
+		{Amount: entities.FromRawAmount(token0, big.NewInt(7000)), Route: r1},
+	}
+	trade, err = FromRoutesSequential(wrappedRoutes, entities.ExactInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	independent, err = FromRoutes(wrappedRoutes, entities.ExactInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, trade.OutputAmount().EqualTo(independent.OutputAmount().Fraction))
+
+	// re-used pools see the price impact of earlier routes for exact output
+	r0, _ = NewRoute([]*Pool{pool_0_1}, token0, token1)
+	r1, _ = NewRoute([]*Pool{pool_0_1}, token0, token1)
+	trade, err = FromRoutesSequential([]*WrappedRoute{
+		{Amount: entities.FromRawAmount(token1, big.NewInt(5000)), Route: r0},
+		{Amount: entities.FromRawAmount(token1, big.NewInt(5000)), Route: r1},
+	}, entities.ExactOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, trade.Swaps[0].InputAmount.LessThan(trade.Swaps[1].InputAmount.Fraction))
+	single, err := FromRoute(r0, entities.FromRawAmount(token1, big.NewInt(10000)), entities.ExactOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := new(big.Int).Sub(trade.InputAmount().Quotient(), single.InputAmount().Quotient())
+	assert.True(t, diff.CmpAbs(big.NewInt(2)) <= 0, "splitting a swap over the same pool costs about the same as one swap")
+
+	// errors if the amount does not match the route
+	_, err = FromRoutesSequential([]*WrappedRoute{
+		{Amount: entities.FromRawAmount(token1, big.NewInt(5000)), Route: r0},
+	}, entities.ExactInput)
+	assert.ErrorIs(t, err, ErrInvalidAmountForRoute)
+}
+
 func TestCreateUncheckedTrade(t *testing.T) {
 	r, _ := NewRoute([]*Pool{pool_0_1}, token0, token1)
 	_, err := CreateUncheckedTrade(r, entities.FromRawAmount(token2, big.NewInt(10000)), entities.FromRawAmount(token1, big.NewInt(10000)), entities.ExactInput)
@@ -468,14 +530,21 @@ func TestBestTradeExactIn(t *testing.T) {
 	assert.Equal(t, len(result[0].Swaps[0].Route.Pools), 1)
 
 	// insufficient input for one pool
-	result, err = BestTradeExactIn([]*Pool{pool_0_1, pool_0_2, pool_1_2}, entities.FromRawAmount(token0, big.NewInt(1)), token2, nil, nil, nil, nil)
+	result, err = BestTradeExactIn([]*Pool{pool_0_1, pool_0_2, pool_1_2}, entities.FromRawAmount(token0, big.NewInt(2)), token2, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	assert.Equal(t, len(result), 2)
+	assert.Equal(t, len(result), 1)
 	assert.Equal(t, len(result[0].Swaps[0].Route.Pools), 1)
 	assert.Equal(t, result[0].Swaps[0].Route.TokenPath, []*entities.Token{token0, token2})
-	assert.True(t, result[0].OutputAmount().EqualTo(entities.FromRawAmount(token2, big.NewInt(0)).Fraction))
+	assert.True(t, result[0].OutputAmount().EqualTo(entities.FromRawAmount(token2, big.NewInt(1)).Fraction))
+
+	// insufficient input for every pool
+	result, err = BestTradeExactIn([]*Pool{pool_0_1, pool_0_2, pool_1_2}, entities.FromRawAmount(token0, big.NewInt(1)), token2, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, result)
 
 	// respects n
 	result, err = BestTradeExactIn([]*Pool{pool_0_1, pool_0_2, pool_1_2}, entities.FromRawAmount(token0, big.NewInt(10)), token2, &BestTradeOptions{MaxNumResults: 1, MaxHops: 3}, nil, nil, nil)
@@ -673,3 +742,22 @@ func TestBestTradeExactOut(t *testing.T) {
 	assert.Equal(t, result[1].Swaps[0].Route.TokenPath, []*entities.Token{token3, token1, token0, entities.WETH9[1]})
 	assert.Equal(t, result[1].OutputAmount().Currency, Ether)
 }
+
+func TestBestTradeSkipsInsufficientLiquidity(t *testing.T) {
+	pool_0_2_ranges := multiRangePool(token0, token2)
+
+	// skips the pool that cannot fill the output
+	result, err := BestTradeExactOut([]*Pool{pool_0_2_ranges, pool_0_1, pool_1_2}, token0, entities.FromRawAmount(token2, big.NewInt(45000)), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0].Swaps[0].Route.TokenPath, []*entities.Token{token0, token1, token2})
+
+	// keeps the pool that can take only part of the input
+	result, err = BestTradeExactIn([]*Pool{pool_0_2_ranges, pool_0_1, pool_1_2}, entities.FromRawAmount(token0, big.NewInt(45000)), token2, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, len(result), 2)
+}
@@ -0,0 +1,61 @@
+// Package testutil holds the fixtures shared by the tests of the SDK packages
+package testutil
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The private key of the account that signs in tests
+var PrivateKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+/**
+ * Hashes an EIP-712 domain with go-ethereum's generic typed data encoder, to check the hand rolled hashes against
+ * @param domain The domain, whose type has only the fields that are set
+ * @returns The domain separator
+ */
+func DomainSeparator(t testing.TB, domain apitypes.TypedDataDomain) []byte {
+	typedData := apitypes.TypedData{Types: apitypes.Types{"EIP712Domain": domainType(domain)}, Domain: domain}
+	separator, err := typedData.HashStruct("EIP712Domain", domain.Map())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return separator
+}
+
+/**
+ * Hashes typed data with go-ethereum's generic typed data encoder, to check the hand rolled hashes against
+ * @param domain The domain, whose type has only the fields that are set
+ * @param primaryType The type of the message
+ * @param types The type of the message and of the structs it references
+ * @param message The message
+ * @returns The digest to sign
+ */
+func TypedDataHash(t testing.TB, domain apitypes.TypedDataDomain, primaryType string, types apitypes.Types, message apitypes.TypedDataMessage) []byte {
+	types["EIP712Domain"] = domainType(domain)
+	typedData := apitypes.TypedData{Types: types, PrimaryType: primaryType, Domain: domain, Message: message}
+	structHash, err := typedData.HashStruct(primaryType, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.Keccak256([]byte("\x19\x01"), DomainSeparator(t, domain), structHash)
+}
+
+func domainType(domain apitypes.TypedDataDomain) []apitypes.Type {
+	var fields []apitypes.Type
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainId != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+	}
+	if domain.VerifyingContract != "" {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+	}
+	return fields
+}
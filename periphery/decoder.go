@@ -0,0 +1,273 @@
+package periphery
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrCalldataTooShort = errors.New("calldata too short")
+	ErrUnknownMethod    = errors.New("unknown method")
+)
+
+// A call decoded from calldata to a router, the position manager or the migrator
+type DecodedCall struct {
+	Method    string      // The name of the method, e.g. exactInput
+	Signature string      // The signature of the method, which tells overloads apart
+	Params    interface{} // The typed parameters of the call, see DecodeCalldata
+	Deadline  *big.Int    // The deadline of the enclosing SwapRouter02 multicall, nil if it has none
+}
+
+type SelfPermitParams struct {
+	Token       common.Address
+	Permit      *PermitOptions // Holds StandardPermitArguments for selfPermit and AllowedPermitArguments for selfPermitAllowed
+	IfNecessary bool           // Whether the permit is only used if the allowance is too low
+}
+
+type NFTPermitParams struct {
+	TokenId *big.Int
+	Permit  *NFTPermitOptions
+}
+
+type UnwrapWETH9Params struct {
+	AmountMinimum *big.Int
+	Recipient     common.Address // MsgSender for the SwapRouter02 overloads without a recipient
+	FeeBips       *big.Int       // The fee in basis points, nil if no fee is taken
+	FeeRecipient  common.Address
+}
+
+type SweepTokenParams struct {
+	Token         common.Address
+	AmountMinimum *big.Int
+	Recipient     common.Address // MsgSender for the SwapRouter02 overloads without a recipient
+	FeeBips       *big.Int       // The fee in basis points, nil if no fee is taken
+	FeeRecipient  common.Address
+}
+
+type RefundETHParams struct{}
+
+type WrapETHParams struct {
+	Value *big.Int
+}
+
+type PullParams struct {
+	Token common.Address
+	Value *big.Int
+}
+
+type CreateAndInitializePoolParams struct {
+	Token0       common.Address
+	Token1       common.Address
+	Fee          *big.Int
+	SqrtPriceX96 *big.Int
+}
+
+type BurnParams struct {
+	TokenId *big.Int
+}
+
+type SafeTransferFromParams struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Data    []byte
+}
+
+// The structs that methods taking a single struct are decoded into, by signature
+var structParams = map[string]func() interface{}{
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))":  func() interface{} { return new(ExactInputSingleParams) },
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint256,uint160))": func() interface{} { return new(ExactOutputSingleParams) },
+	"exactInput((bytes,address,uint256,uint256,uint256))":                                 func() interface{} { return new(ExactInputParams) },
+	"exactOutput((bytes,address,uint256,uint256,uint256))":                                func() interface{} { return new(ExactOutputParams) },
+	"exactInputSingle((address,address,uint24,address,uint256,uint256,uint160))":          func() interface{} { return new(Router02ExactInputSingleParams) },
+	"exactOutputSingle((address,address,uint24,address,uint256,uint256,uint160))":         func() interface{} { return new(Router02ExactOutputSingleParams) },
+	"exactInput((bytes,address,uint256,uint256))":                                         func() interface{} { return new(Router02ExactInputParams) },
+	"exactOutput((bytes,address,uint256,uint256))":                                        func() interface{} { return new(Router02ExactOutputParams) },
+	"mint((address,address,uint24,int24,int24,uint256,uint256,uint256,uint256,address,uint256))": func() interface{} {
+		return new(MintParams)
+	},
+	"increaseLiquidity((uint256,uint256,uint256,uint256,uint256,uint256))": func() interface{} { return new(IncreaseLiquidityParams) },
+	"decreaseLiquidity((uint256,uint128,uint256,uint256,uint256))":         func() interface{} { return new(DecreaseLiquidityParams) },
+	"collect((uint256,address,uint128,uint128))":                           func() interface{} { return new(CollectParams) },
+	"migrate((address,uint256,uint8,address,address,uint24,int24,int24,uint256,uint256,address,uint256,bool))": func() interface{} {
+		return new(MigrateParams)
+	},
+}
+
+var (
+	decoderMethodsOnce sync.Once
+	decoderMethods     map[[4]byte]abi.Method
+)
+
+// getDecoderMethods returns the methods of the routers, the position manager and the migrator by selector
+func getDecoderMethods() map[[4]byte]abi.Method {
+	decoderMethodsOnce.Do(func() {
+		decoderMethods = make(map[[4]byte]abi.Method)
+		for _, contract := range [][]byte{multicallABI, selfpermitABI, paymentsABI, swapRouterABI, swapRouter02ABI, nonFungiblePositionManagerABI, migratorABI} {
+			for _, method := range GetABI(contract).Methods {
+				var id [4]byte
+				copy(id[:], method.ID)
+				decoderMethods[id] = method
+			}
+		}
+	})
+	return decoderMethods
+}
+
+/**
+ * Decodes calldata sent to SwapRouter, SwapRouter02, the NonfungiblePositionManager or the V3Migrator,
+ * unwrapping multicalls recursively. It is the inverse of SwapCallParameters, AddCallParameters and the other encoders.
+ * The params of the calls are:
+ * - the swap structs, e.g. *ExactInputParams, or *Router02ExactInputParams for SwapRouter02
+ * - *MintParams, *IncreaseLiquidityParams, *DecreaseLiquidityParams, *CollectParams and *MigrateParams
+ * - *SelfPermitParams and *NFTPermitParams for permits
+ * - *UnwrapWETH9Params, *SweepTokenParams, *RefundETHParams, *WrapETHParams and *PullParams for payments
+ * - *CreateAndInitializePoolParams, *BurnParams and *SafeTransferFromParams
+ * - the unpacked arguments as a []interface{} for any other method of the contracts
+ * @param data The calldata
+ * @returns The calls that the calldata makes, in order
+ */
+func DecodeCalldata(data []byte) ([]*DecodedCall, error) {
+	return decodeCalldata(data, nil)
+}
+
+func decodeCalldata(data []byte, deadline *big.Int) ([]*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, ErrCalldataTooShort
+	}
+	var id [4]byte
+	copy(id[:], data[:4])
+	method, ok := getDecoderMethods()[id]
+	if !ok {
+		return nil, ErrUnknownMethod
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	// unwrap multicalls and calls forwarded to the position manager
+	var inner [][]byte
+	switch method.Sig {
+	case "multicall(bytes[])", "multicall(bytes32,bytes[])":
+		inner = args[len(args)-1].([][]byte)
+	case "multicall(uint256,bytes[])":
+		deadline = args[0].(*big.Int)
+		inner = args[1].([][]byte)
+	case "callPositionManager(bytes)":
+		inner = [][]byte{args[0].([]byte)}
+	}
+	if inner != nil {
+		var calls []*DecodedCall
+		for _, calldata := range inner {
+			decoded, err := decodeCalldata(calldata, deadline)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, decoded...)
+		}
+		return calls, nil
+	}
+
+	return []*DecodedCall{{
+		Method:    method.RawName,
+		Signature: method.Sig,
+		Params:    decodeParams(&method, args),
+		Deadline:  deadline,
+	}}, nil
+}
+
+// decodeParams converts the unpacked arguments of a method into its typed params
+func decodeParams(method *abi.Method, args []interface{}) interface{} {
+	if proto, ok := structParams[method.Sig]; ok {
+		return abi.ConvertType(args[0], proto())
+	}
+
+	switch method.RawName {
+	case "selfPermit", "selfPermitIfNecessary":
+		return &SelfPermitParams{
+			Token: args[0].(common.Address),
+			Permit: &PermitOptions{StandardPermitArguments: &StandardPermitArguments{
+				Amount:   args[1].(*big.Int),
+				Deadline: args[2].(*big.Int),
+				V:        args[3].(uint8),
+				R:        args[4].([32]byte),
+				S:        args[5].([32]byte),
+			}},
+			IfNecessary: method.RawName == "selfPermitIfNecessary",
+		}
+	case "selfPermitAllowed", "selfPermitAllowedIfNecessary":
+		return &SelfPermitParams{
+			Token: args[0].(common.Address),
+			Permit: &PermitOptions{AllowedPermitArguments: &AllowedPermitArguments{
+				Nonce:  args[1].(*big.Int),
+				Expiry: args[2].(*big.Int),
+				V:      args[3].(uint8),
+				R:      args[4].([32]byte),
+				S:      args[5].([32]byte),
+			}},
+			IfNecessary: method.RawName == "selfPermitAllowedIfNecessary",
+		}
+	case "permit":
+		return &NFTPermitParams{
+			TokenId: args[1].(*big.Int),
+			Permit: &NFTPermitOptions{
+				Spender:  args[0].(common.Address),
+				Deadline: args[2].(*big.Int),
+				V:        args[3].(uint8),
+				R:        args[4].([32]byte),
+				S:        args[5].([32]byte),
+			},
+		}
+	case "unwrapWETH9", "unwrapWETH9WithFee":
+		params := &UnwrapWETH9Params{AmountMinimum: args[0].(*big.Int), Recipient: MsgSender}
+		rest := args[1:]
+		if len(args) == 2 || len(args) == 4 {
+			params.Recipient = rest[0].(common.Address)
+			rest = rest[1:]
+		}
+		if len(rest) == 2 {
+			params.FeeBips = rest[0].(*big.Int)
+			params.FeeRecipient = rest[1].(common.Address)
+		}
+		return params
+	case "sweepToken", "sweepTokenWithFee":
+		params := &SweepTokenParams{Token: args[0].(common.Address), AmountMinimum: args[1].(*big.Int), Recipient: MsgSender}
+		rest := args[2:]
+		if len(args) == 3 || len(args) == 5 {
+			params.Recipient = rest[0].(common.Address)
+			rest = rest[1:]
+		}
+		if len(rest) == 2 {
+			params.FeeBips = rest[0].(*big.Int)
+			params.FeeRecipient = rest[1].(common.Address)
+		}
+		return params
+	case "refundETH":
+		return &RefundETHParams{}
+	case "wrapETH":
+		return &WrapETHParams{Value: args[0].(*big.Int)}
+	case "pull":
+		return &PullParams{Token: args[0].(common.Address), Value: args[1].(*big.Int)}
+	case "createAndInitializePoolIfNecessary":
+		return &CreateAndInitializePoolParams{
+			Token0:       args[0].(common.Address),
+			Token1:       args[1].(common.Address),
+			Fee:          args[2].(*big.Int),
+			SqrtPriceX96: args[3].(*big.Int),
+		}
+	case "burn":
+		return &BurnParams{TokenId: args[0].(*big.Int)}
+	case "safeTransferFrom":
+		params := &SafeTransferFromParams{From: args[0].(common.Address), To: args[1].(common.Address), TokenId: args[2].(*big.Int)}
+		if len(args) == 4 {
+			params.Data = args[3].([]byte)
+		}
+		return params
+	}
+	return args
+}
@@ -0,0 +1,181 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCalldataAddLiquidity(t *testing.T) {
+	spacing := constants.TickSpacings[constants.FeeMedium]
+	pos, _ := entities.NewPosition(pool01T, big.NewInt(1), -spacing, spacing)
+	permit := &PermitOptions{StandardPermitArguments: &StandardPermitArguments{V: 27, R: [32]byte{1}, S: [32]byte{2}, Amount: big.NewInt(10), Deadline: deadlineT}}
+	params, err := AddCallParameters(pos, &AddLiquidityOptions{
+		MintSpecificOptions: &MintSpecificOptions{Recipient: recipientT, CreatePool: true},
+		CommonAddLiquidityOptions: &CommonAddLiquidityOptions{
+			SlippageTolerance: slippageToleranceT,
+			Deadline:          deadlineT,
+			Token0Permit:      permit,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := DecodeCalldata(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(calls))
+
+	create := calls[0].Params.(*CreateAndInitializePoolParams)
+	assert.Equal(t, "createAndInitializePoolIfNecessary", calls[0].Method)
+	assert.Equal(t, token0T.Address, create.Token0)
+	assert.Equal(t, token1T.Address, create.Token1)
+	assert.Equal(t, big.NewInt(3000), create.Fee)
+	assert.Equal(t, pool01T.SqrtRatioX96, create.SqrtPriceX96)
+
+	selfPermit := calls[1].Params.(*SelfPermitParams)
+	assert.Equal(t, token0T.Address, selfPermit.Token)
+	assert.Equal(t, permit, selfPermit.Permit)
+	assert.False(t, selfPermit.IfNecessary)
+
+	mint := calls[2].Params.(*MintParams)
+	assert.Equal(t, "mint", calls[2].Method)
+	assert.Equal(t, big.NewInt(int64(-spacing)), mint.TickLower)
+	assert.Equal(t, big.NewInt(1), mint.Amount0Desired)
+	assert.Equal(t, recipientT, mint.Recipient)
+	assert.Equal(t, deadlineT, mint.Deadline)
+	assert.Nil(t, calls[2].Deadline)
+}
+
+func TestDecodeCalldataRemoveLiquidity(t *testing.T) {
+	spacing := constants.TickSpacings[constants.FeeMedium]
+	pos, _ := entities.NewPosition(pool1wethT, big.NewInt(100), -spacing, spacing)
+	weth := core.WETH9[1]
+	owed0, owed1 := core.FromRawAmount(token1T, big.NewInt(0)), core.FromRawAmount(core.EtherOnChain(1), big.NewInt(0))
+	var token0, token1 *core.Token = token1T, weth
+	if !pool1wethT.Token0.Equal(token1T) {
+		owed0, owed1 = owed1, owed0
+		token0, token1 = token1, token0
+	}
+	nftPermit := &NFTPermitOptions{V: 28, R: [32]byte{3}, S: [32]byte{4}, Deadline: deadlineT, Spender: senderT}
+	params, err := RemoveCallParameters(pos, &RemoveLiquidityOptions{
+		TokenID:             tokenIDT,
+		LiquidityPercentage: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Deadline:            deadlineT,
+		BurnToken:           true,
+		Permit:              nftPermit,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: owed0,
+			ExpectedCurrencyOwed1: owed1,
+			ExpectedTokenOwed0:    token0,
+			ExpectedTokenOwed1:    token1,
+			Recipient:             recipientT,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := DecodeCalldata(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var methods []string
+	for _, call := range calls {
+		methods = append(methods, call.Method)
+	}
+	assert.Equal(t, []string{"permit", "decreaseLiquidity", "collect", "unwrapWETH9", "sweepToken", "burn"}, methods)
+
+	permit := calls[0].Params.(*NFTPermitParams)
+	assert.Equal(t, tokenIDT, permit.TokenId)
+	assert.Equal(t, nftPermit, permit.Permit)
+
+	decrease := calls[1].Params.(*DecreaseLiquidityParams)
+	assert.Equal(t, tokenIDT, decrease.TokenId)
+	assert.Equal(t, big.NewInt(100), decrease.Liquidity)
+	assert.Equal(t, deadlineT, decrease.Deadline)
+
+	collect := calls[2].Params.(*CollectParams)
+	assert.Equal(t, constants.AddressZero, collect.Recipient)
+	assert.Equal(t, MaxUint128, collect.Amount0Max)
+
+	unwrap := calls[3].Params.(*UnwrapWETH9Params)
+	assert.Equal(t, recipientT, unwrap.Recipient)
+	assert.Nil(t, unwrap.FeeBips)
+
+	sweep := calls[4].Params.(*SweepTokenParams)
+	assert.Equal(t, token1T.Address, sweep.Token)
+	assert.Equal(t, recipientT, sweep.Recipient)
+
+	assert.Equal(t, tokenIDT, calls[5].Params.(*BurnParams).TokenId)
+}
+
+func TestDecodeCalldataSwap(t *testing.T) {
+	pool_0_1 := makePool(token0, token1)
+	pool_1_weth := makePool(token1, weth)
+
+	// SwapRouter02 with a deadline, ETH out and a fee
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1, pool_1_weth}, token0, ether)
+	trade, _ := entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	feeRecipient := common.HexToAddress("0x0000000000000000000000000000000000000009")
+	params, err := SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: core.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Deadline:          big.NewInt(123),
+		Fee:               &FeeOptions{Fee: core.NewPercent(big.NewInt(5), big.NewInt(1000)), Recipient: feeRecipient},
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := DecodeCalldata(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(calls))
+	swap := calls[0].Params.(*Router02ExactInputParams)
+	assert.Equal(t, "exactInput((bytes,address,uint256,uint256))", calls[0].Signature)
+	assert.Equal(t, AddressThis, swap.Recipient)
+	assert.Equal(t, big.NewInt(100), swap.AmountIn)
+	assert.Equal(t, big.NewInt(123), calls[0].Deadline)
+	unwrap := calls[1].Params.(*UnwrapWETH9Params)
+	assert.Equal(t, MsgSender, unwrap.Recipient)
+	assert.Equal(t, swap.AmountOutMinimum, unwrap.AmountMinimum)
+	assert.Equal(t, big.NewInt(50), unwrap.FeeBips)
+	assert.Equal(t, feeRecipient, unwrap.FeeRecipient)
+	assert.Equal(t, big.NewInt(123), calls[1].Deadline)
+
+	// a single SwapRouter call is not wrapped in a multicall
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactOutput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: core.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Recipient:         recipientT,
+		Deadline:          big.NewInt(123),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err = DecodeCalldata(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(calls))
+	single := calls[0].Params.(*ExactOutputSingleParams)
+	assert.Equal(t, token0.Address, single.TokenIn)
+	assert.Equal(t, token1.Address, single.TokenOut)
+	assert.Equal(t, recipientT, single.Recipient)
+	assert.Equal(t, big.NewInt(100), single.AmountOut)
+	assert.Equal(t, big.NewInt(123), single.Deadline)
+	assert.Nil(t, calls[0].Deadline)
+
+	_, err = DecodeCalldata([]byte{1, 2})
+	assert.Equal(t, ErrCalldataTooShort, err)
+	_, err = DecodeCalldata([]byte{1, 2, 3, 4})
+	assert.Equal(t, ErrUnknownMethod, err)
+}
@@ -0,0 +1,113 @@
+package periphery
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrPermitOwnerMismatch = errors.New("signer is not the permit owner")
+
+var erc20PermitTypeHash = crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+
+// ERC20Permit is an EIP-2612 permit for a spender to spend an owner's tokens
+type ERC20Permit struct {
+	Domain   *utils.EIP712Domain // The domain of the token, see NewERC20PermitDomain
+	Owner    common.Address      // The account that owns the tokens and signs the permit
+	Spender  common.Address      // The account allowed to spend the tokens, e.g. the router for selfPermit
+	Value    *big.Int            // The amount allowed to be spent
+	Nonce    *big.Int            // The owner's current nonce on the token, from nonces(owner)
+	Deadline *big.Int            // When the permit expires, in epoch seconds
+}
+
+/**
+ * Returns the EIP-712 domain of a token's permits
+ * @param token The token
+ * @param name The name the token signs permits with, usually its on-chain name()
+ * @param version The version the token signs permits with, usually "1"
+ */
+func NewERC20PermitDomain(token *entities.Token, name, version string) *utils.EIP712Domain {
+	return &utils.EIP712Domain{
+		Name:              name,
+		Version:           version,
+		ChainID:           new(big.Int).SetUint64(uint64(token.ChainId())),
+		VerifyingContract: token.Address,
+	}
+}
+
+// Hash returns the EIP-712 struct hash of the permit
+func (p *ERC20Permit) Hash() []byte {
+	return crypto.Keccak256(
+		erc20PermitTypeHash,
+		utils.EncodeAddress(p.Owner),
+		utils.EncodeAddress(p.Spender),
+		utils.EncodeUint256(p.Value),
+		utils.EncodeUint256(p.Nonce),
+		utils.EncodeUint256(p.Deadline),
+	)
+}
+
+// TypedDataHash returns the digest the owner signs
+func (p *ERC20Permit) TypedDataHash() []byte {
+	return utils.TypedDataHash(p.Domain, p.Hash())
+}
+
+/**
+ * Signs an EIP-2612 permit
+ * @param signer The owner of the tokens
+ * @param permit The permit to sign
+ * @returns The permit options to pass to EncodePermit
+ */
+func SignERC20Permit(signer utils.Signer, permit *ERC20Permit) (*PermitOptions, error) {
+	if signer.Address() != permit.Owner {
+		return nil, ErrPermitOwnerMismatch
+	}
+	signature, err := signer.SignHash(permit.TypedDataHash())
+	if err != nil {
+		return nil, err
+	}
+	v, r, s, err := utils.SplitSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return &PermitOptions{
+		StandardPermitArguments: &StandardPermitArguments{
+			V:        v,
+			R:        r,
+			S:        s,
+			Amount:   permit.Value,
+			Deadline: permit.Deadline,
+		},
+	}, nil
+}
+
+/**
+ * Recovers the account that signed an EIP-2612 permit
+ * @param permit The permit that was signed
+ * @param args The signature of the permit
+ * @returns The address of the signer
+ */
+func RecoverERC20PermitSigner(permit *ERC20Permit, args *StandardPermitArguments) (common.Address, error) {
+	signature := append(append(append([]byte{}, args.R[:]...), args.S[:]...), args.V)
+	return utils.RecoverSigner(permit.TypedDataHash(), signature)
+}
+
+/**
+ * Returns whether permit arguments are a valid signature of an EIP-2612 permit by its owner
+ * @param permit The permit that should have been signed
+ * @param args The permit arguments to verify
+ */
+func VerifyERC20Permit(permit *ERC20Permit, args *StandardPermitArguments) bool {
+	if args.Amount.Cmp(permit.Value) != 0 || args.Deadline.Cmp(permit.Deadline) != 0 {
+		return false
+	}
+	signer, err := RecoverERC20PermitSigner(permit, args)
+	if err != nil {
+		return false
+	}
+	return signer == permit.Owner
+}
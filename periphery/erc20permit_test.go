@@ -0,0 +1,83 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/internal/testutil"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignERC20Permit(t *testing.T) {
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	spender := common.HexToAddress("0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45")
+	permit := &ERC20Permit{
+		Domain:   NewERC20PermitDomain(token0, "token0", "1"),
+		Owner:    signer.Address(),
+		Spender:  spender,
+		Value:    big.NewInt(123),
+		Nonce:    big.NewInt(4),
+		Deadline: big.NewInt(1690000000),
+	}
+
+	expected := testutil.TypedDataHash(t, apitypes.TypedDataDomain{
+		Name:              "token0",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: token0.Address.Hex(),
+	}, "Permit", apitypes.Types{
+		"Permit": {
+			{Name: "owner", Type: "address"},
+			{Name: "spender", Type: "address"},
+			{Name: "value", Type: "uint256"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+	}, apitypes.TypedDataMessage{
+		"owner":    signer.Address().Hex(),
+		"spender":  spender.Hex(),
+		"value":    "123",
+		"nonce":    "4",
+		"deadline": "1690000000",
+	})
+	assert.Equal(t, expected, permit.TypedDataHash())
+
+	options, err := SignERC20Permit(signer, permit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, options.StandardPermitArguments.V == 27 || options.StandardPermitArguments.V == 28)
+	assert.Equal(t, permit.Value, options.StandardPermitArguments.Amount)
+	assert.Equal(t, permit.Deadline, options.StandardPermitArguments.Deadline)
+	assert.True(t, VerifyERC20Permit(permit, options.StandardPermitArguments))
+	owner, err := RecoverERC20PermitSigner(permit, options.StandardPermitArguments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), owner)
+
+	encoded, err := EncodePermit(token0, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xf3995c67", hexutil.Encode(encoded[:4]))
+
+	// a signature of different terms does not verify
+	other := *permit
+	other.Nonce = big.NewInt(5)
+	assert.False(t, VerifyERC20Permit(&other, options.StandardPermitArguments))
+	other = *permit
+	other.Value = big.NewInt(124)
+	assert.False(t, VerifyERC20Permit(&other, options.StandardPermitArguments))
+
+	// only the owner can sign
+	other = *permit
+	other.Owner = spender
+	_, err = SignERC20Permit(signer, &other)
+	assert.Equal(t, ErrPermitOwnerMismatch, err)
+}
@@ -0,0 +1,377 @@
+package periphery
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+)
+
+// The longest a deadline may be after the current time before it is flagged, by default
+const DefaultMaxDeadlineDelay = time.Hour
+
+// A pattern in a transaction that may lose the sender funds
+type RiskKind int
+
+const (
+	RiskZeroMinimumOut   RiskKind = iota // An action gives out tokens without requiring a minimum amount
+	RiskForeignRecipient                 // An action sends tokens or an NFT to an account other than the sender
+	RiskFarDeadline                      // A deadline is far in the future, so the transaction or permit can be used much later
+)
+
+type Risk struct {
+	Kind    RiskKind
+	Action  int    // The index of the action the risk was found in
+	Message string // A description of the risk
+}
+
+type ExplainedAction struct {
+	Call        *DecodedCall
+	Description string // A human-readable description of the call
+}
+
+// A human-readable description of the actions a transaction takes
+type Explanation struct {
+	Actions []ExplainedAction // The actions, in the order they are executed
+	Value   string            // The ETH sent with the transaction, formatted in ether
+	Risks   []Risk
+}
+
+// Options for explaining method parameters
+type ExplainOptions struct {
+	Sender           common.Address // The account that will send the transaction. If set, recipients other than it are flagged
+	Tokens           []*core.Token  // Tokens whose symbols and decimals are used for formatting, other tokens are shown by address with raw amounts
+	Now              time.Time      // The time deadlines are compared to, the current time by default
+	MaxDeadlineDelay time.Duration  // The longest a deadline may be after now before it is flagged, DefaultMaxDeadlineDelay by default
+}
+
+// explainer holds the state of explaining the actions of a transaction
+type explainer struct {
+	opts         *ExplainOptions
+	tokens       map[common.Address]*core.Token
+	now          time.Time
+	maxDelay     time.Duration
+	lastDeadline *big.Int
+	explanation  *Explanation
+}
+
+/**
+ * Explains the method parameters produced by any of the periphery encoders
+ * @param params The method parameters
+ * @param opts Options for the explanation, may be nil
+ * @returns The actions of the transaction, its value and the risky patterns found in it
+ */
+func ExplainMethodParameters(params *utils.MethodParameters, opts *ExplainOptions) (*Explanation, error) {
+	calls, err := DecodeCalldata(params.Calldata)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &ExplainOptions{}
+	}
+	e := &explainer{
+		opts:        opts,
+		tokens:      make(map[common.Address]*core.Token),
+		now:         opts.Now,
+		maxDelay:    opts.MaxDeadlineDelay,
+		explanation: &Explanation{Value: formatAmount(params.Value, 18) + " ETH"},
+	}
+	for _, token := range opts.Tokens {
+		e.tokens[token.Address] = token
+	}
+	if e.now.IsZero() {
+		e.now = time.Now()
+	}
+	if e.maxDelay == 0 {
+		e.maxDelay = DefaultMaxDeadlineDelay
+	}
+
+	for i, call := range calls {
+		if call.Deadline != nil && call.Deadline != e.lastDeadline {
+			e.checkDeadline(i, call.Deadline, "transaction")
+			e.lastDeadline = call.Deadline
+		}
+		e.explanation.Actions = append(e.explanation.Actions, ExplainedAction{
+			Call:        call,
+			Description: e.describe(i, call),
+		})
+	}
+	return e.explanation, nil
+}
+
+// String renders the explanation as numbered actions followed by the value and the risks
+func (e *Explanation) String() string {
+	var b strings.Builder
+	for i, action := range e.Actions {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, action.Description)
+	}
+	fmt.Fprintf(&b, "value: %s\n", e.Value)
+	for _, risk := range e.Risks {
+		fmt.Fprintf(&b, "risk in action %d: %s\n", risk.Action+1, risk.Message)
+	}
+	return b.String()
+}
+
+// describe describes a call, flagging its risks
+func (e *explainer) describe(i int, call *DecodedCall) string {
+	switch p := call.Params.(type) {
+	case *ExactInputSingleParams:
+		e.checkMinimumOut(i, p.AmountOutMinimum)
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "swap")
+		return e.describeSwap(true, p.TokenIn, p.TokenOut, p.AmountIn, p.AmountOutMinimum, p.Fee, p.Recipient) + until(p.Deadline)
+	case *Router02ExactInputSingleParams:
+		e.checkMinimumOut(i, p.AmountOutMinimum)
+		e.checkRecipient(i, p.Recipient)
+		return e.describeSwap(true, p.TokenIn, p.TokenOut, p.AmountIn, p.AmountOutMinimum, p.Fee, p.Recipient)
+	case *ExactOutputSingleParams:
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "swap")
+		return e.describeSwap(false, p.TokenIn, p.TokenOut, p.AmountInMaximum, p.AmountOut, p.Fee, p.Recipient) + until(p.Deadline)
+	case *Router02ExactOutputSingleParams:
+		e.checkRecipient(i, p.Recipient)
+		return e.describeSwap(false, p.TokenIn, p.TokenOut, p.AmountInMaximum, p.AmountOut, p.Fee, p.Recipient)
+	case *ExactInputParams:
+		e.checkMinimumOut(i, p.AmountOutMinimum)
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "swap")
+		return e.describePathSwap(true, p.Path, p.AmountIn, p.AmountOutMinimum, p.Recipient) + until(p.Deadline)
+	case *Router02ExactInputParams:
+		e.checkMinimumOut(i, p.AmountOutMinimum)
+		e.checkRecipient(i, p.Recipient)
+		return e.describePathSwap(true, p.Path, p.AmountIn, p.AmountOutMinimum, p.Recipient)
+	case *ExactOutputParams:
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "swap")
+		return e.describePathSwap(false, p.Path, p.AmountInMaximum, p.AmountOut, p.Recipient) + until(p.Deadline)
+	case *Router02ExactOutputParams:
+		e.checkRecipient(i, p.Recipient)
+		return e.describePathSwap(false, p.Path, p.AmountInMaximum, p.AmountOut, p.Recipient)
+	case *MintParams:
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "mint")
+		return fmt.Sprintf("mint a %s position in ticks [%s, %s] with up to %s (at least %s) and %s (at least %s) to %s",
+			e.describePool(p.Token0, p.Token1, p.Fee), p.TickLower, p.TickUpper,
+			e.formatTokenAmount(p.Token0, p.Amount0Desired), e.formatTokenAmount(p.Token0, p.Amount0Min),
+			e.formatTokenAmount(p.Token1, p.Amount1Desired), e.formatTokenAmount(p.Token1, p.Amount1Min),
+			e.formatRecipient(p.Recipient)) + until(p.Deadline)
+	case *IncreaseLiquidityParams:
+		e.checkDeadline(i, p.Deadline, "increase")
+		return fmt.Sprintf("add up to %s (at least %s) of token0 and %s (at least %s) of token1 to position #%s",
+			p.Amount0Desired, p.Amount0Min, p.Amount1Desired, p.Amount1Min, p.TokenId) + until(p.Deadline)
+	case *DecreaseLiquidityParams:
+		if p.Amount0Min.Sign() == 0 && p.Amount1Min.Sign() == 0 {
+			e.flag(RiskZeroMinimumOut, i, "liquidity is removed without a minimum amount of either token")
+		}
+		e.checkDeadline(i, p.Deadline, "decrease")
+		return fmt.Sprintf("remove %s liquidity from position #%s for at least %s of token0 and %s of token1",
+			p.Liquidity, p.TokenId, p.Amount0Min, p.Amount1Min) + until(p.Deadline)
+	case *CollectParams:
+		// the position manager collects to itself for a zero recipient, to unwrap or sweep the tokens after
+		recipient := p.Recipient
+		if recipient == constants.AddressZero {
+			recipient = AddressThis
+		}
+		e.checkRecipient(i, recipient)
+		return fmt.Sprintf("collect %s of token0 and %s of token1 owed to position #%s to %s",
+			formatMaxAmount(p.Amount0Max), formatMaxAmount(p.Amount1Max), p.TokenId, e.formatRecipient(recipient))
+	case *MigrateParams:
+		if p.Amount0Min.Sign() == 0 && p.Amount1Min.Sign() == 0 {
+			e.flag(RiskZeroMinimumOut, i, "liquidity is migrated without a minimum amount of either token")
+		}
+		e.checkRecipient(i, p.Recipient)
+		e.checkDeadline(i, p.Deadline, "migration")
+		return fmt.Sprintf("migrate %d%% of %s liquidity of V2 pair %s into a %s position in ticks [%s, %s] for at least %s and %s to %s",
+			p.PercentageToMigrate, p.LiquidityToMigrate, p.Pair.Hex(), e.describePool(p.Token0, p.Token1, p.Fee), p.TickLower, p.TickUpper,
+			e.formatTokenAmount(p.Token0, p.Amount0Min), e.formatTokenAmount(p.Token1, p.Amount1Min), e.formatRecipient(p.Recipient)) + until(p.Deadline)
+	case *SelfPermitParams:
+		if args := p.Permit.StandardPermitArguments; args != nil {
+			e.checkDeadline(i, args.Deadline, "permit")
+			return fmt.Sprintf("permit the contract to spend %s of the sender's %s", e.formatQuantity(p.Token, args.Amount), e.formatToken(p.Token)) +
+				until(args.Deadline)
+		}
+		// a DAI-style permit with a zero expiry never expires
+		args := p.Permit.AllowedPermitArguments
+		description := fmt.Sprintf("permit the contract to spend an unlimited amount of the sender's %s", e.formatToken(p.Token))
+		if args.Expiry.Sign() == 0 {
+			e.flag(RiskFarDeadline, i, "the permit never expires")
+			return description + " with no expiry"
+		}
+		e.checkDeadline(i, args.Expiry, "permit")
+		return description + until(args.Expiry)
+	case *NFTPermitParams:
+		e.checkDeadline(i, p.Permit.Deadline, "permit")
+		return fmt.Sprintf("permit %s to operate position #%s", p.Permit.Spender.Hex(), p.TokenId) + until(p.Permit.Deadline)
+	case *UnwrapWETH9Params:
+		e.checkRecipient(i, p.Recipient)
+		return fmt.Sprintf("unwrap at least %s WETH and send the ETH to %s", formatAmount(p.AmountMinimum, 18), e.formatRecipient(p.Recipient)) +
+			describeFee(p.FeeBips, p.FeeRecipient)
+	case *SweepTokenParams:
+		e.checkRecipient(i, p.Recipient)
+		return fmt.Sprintf("sweep at least %s to %s", e.formatTokenAmount(p.Token, p.AmountMinimum), e.formatRecipient(p.Recipient)) +
+			describeFee(p.FeeBips, p.FeeRecipient)
+	case *RefundETHParams:
+		return "refund any unspent ETH to the sender"
+	case *WrapETHParams:
+		return fmt.Sprintf("wrap %s ETH into WETH", formatAmount(p.Value, 18))
+	case *PullParams:
+		return fmt.Sprintf("pull %s from the sender", e.formatTokenAmount(p.Token, p.Value))
+	case *CreateAndInitializePoolParams:
+		return fmt.Sprintf("create and initialize the %s pool if necessary", e.describePool(p.Token0, p.Token1, p.Fee))
+	case *BurnParams:
+		return fmt.Sprintf("burn position #%s", p.TokenId)
+	case *SafeTransferFromParams:
+		e.checkRecipient(i, p.To)
+		return fmt.Sprintf("transfer position #%s from %s to %s", p.TokenId, p.From.Hex(), e.formatRecipient(p.To))
+	}
+	return "call " + call.Signature
+}
+
+func (e *explainer) describeSwap(exactInput bool, tokenIn, tokenOut common.Address, amountIn, amountOut, fee *big.Int, recipient common.Address) string {
+	route := fmt.Sprintf("%s -%s-> %s", e.formatToken(tokenIn), formatFee(fee), e.formatToken(tokenOut))
+	if exactInput {
+		return fmt.Sprintf("swap exactly %s for at least %s via %s to %s",
+			e.formatTokenAmount(tokenIn, amountIn), e.formatTokenAmount(tokenOut, amountOut), route, e.formatRecipient(recipient))
+	}
+	return fmt.Sprintf("swap at most %s for exactly %s via %s to %s",
+		e.formatTokenAmount(tokenIn, amountIn), e.formatTokenAmount(tokenOut, amountOut), route, e.formatRecipient(recipient))
+}
+
+func (e *explainer) describePathSwap(exactInput bool, path []byte, amountIn, amountOut *big.Int, recipient common.Address) string {
+	tokens, fees, err := DecodePath(path)
+	if err != nil {
+		return fmt.Sprintf("swap with an invalid path 0x%x", path)
+	}
+	// exact output paths are encoded from the output token
+	if !exactInput {
+		reverse(tokens)
+		reverse(fees)
+	}
+	route := e.formatToken(tokens[0])
+	for j, fee := range fees {
+		route += fmt.Sprintf(" -%s-> %s", formatFee(big.NewInt(int64(fee))), e.formatToken(tokens[j+1]))
+	}
+	tokenIn, tokenOut := tokens[0], tokens[len(tokens)-1]
+	if exactInput {
+		return fmt.Sprintf("swap exactly %s for at least %s via %s to %s",
+			e.formatTokenAmount(tokenIn, amountIn), e.formatTokenAmount(tokenOut, amountOut), route, e.formatRecipient(recipient))
+	}
+	return fmt.Sprintf("swap at most %s for exactly %s via %s to %s",
+		e.formatTokenAmount(tokenIn, amountIn), e.formatTokenAmount(tokenOut, amountOut), route, e.formatRecipient(recipient))
+}
+
+func (e *explainer) describePool(token0, token1 common.Address, fee *big.Int) string {
+	return fmt.Sprintf("%s/%s %s", e.formatToken(token0), e.formatToken(token1), formatFee(fee))
+}
+
+func until(deadline *big.Int) string {
+	if !deadline.IsInt64() {
+		return " with " + formatDeadline(deadline)
+	}
+	return " until " + formatDeadline(deadline)
+}
+
+func (e *explainer) flag(kind RiskKind, i int, message string) {
+	e.explanation.Risks = append(e.explanation.Risks, Risk{Kind: kind, Action: i, Message: message})
+}
+
+func (e *explainer) checkMinimumOut(i int, amountOutMinimum *big.Int) {
+	if amountOutMinimum.Sign() == 0 {
+		e.flag(RiskZeroMinimumOut, i, "the swap has no minimum amount out, so it can be sandwiched for all of its output")
+	}
+}
+
+func (e *explainer) checkRecipient(i int, recipient common.Address) {
+	if recipient == constants.AddressZero {
+		e.flag(RiskForeignRecipient, i, "the recipient is the zero address, so the tokens are lost")
+		return
+	}
+	if e.opts.Sender == constants.AddressZero || isSelfRecipient(recipient) || recipient == e.opts.Sender {
+		return
+	}
+	e.flag(RiskForeignRecipient, i, fmt.Sprintf("the recipient %s is not the sender", recipient.Hex()))
+}
+
+func (e *explainer) checkDeadline(i int, deadline *big.Int, what string) {
+	if !deadline.IsInt64() {
+		e.flag(RiskFarDeadline, i, fmt.Sprintf("the %s has %s", what, formatDeadline(deadline)))
+		return
+	}
+	if deadline.Cmp(big.NewInt(e.now.Add(e.maxDelay).Unix())) > 0 {
+		e.flag(RiskFarDeadline, i, fmt.Sprintf("the %s deadline %s is more than %s away", what, formatDeadline(deadline), e.maxDelay))
+	}
+}
+
+// formatDeadline formats a deadline in epoch seconds, which is past any time for the max uint256 commonly used for none
+func formatDeadline(deadline *big.Int) string {
+	if !deadline.IsInt64() {
+		return "no deadline (max uint256)"
+	}
+	return time.Unix(deadline.Int64(), 0).UTC().Format(time.RFC3339)
+}
+
+func (e *explainer) formatToken(address common.Address) string {
+	if token, ok := e.tokens[address]; ok {
+		return token.Symbol()
+	}
+	return address.Hex()
+}
+
+func (e *explainer) formatTokenAmount(address common.Address, amount *big.Int) string {
+	if _, ok := e.tokens[address]; ok {
+		return e.formatQuantity(address, amount) + " " + e.formatToken(address)
+	}
+	return e.formatQuantity(address, amount) + " of " + e.formatToken(address)
+}
+
+// formatQuantity formats an amount of a token without the token, adjusted for its decimals if it is known
+func (e *explainer) formatQuantity(address common.Address, amount *big.Int) string {
+	if token, ok := e.tokens[address]; ok {
+		return formatAmount(amount, token.Decimals())
+	}
+	return amount.String()
+}
+
+func (e *explainer) formatRecipient(recipient common.Address) string {
+	switch {
+	case recipient == MsgSender || (recipient == e.opts.Sender && recipient != constants.AddressZero):
+		return "the sender"
+	case isSelfRecipient(recipient):
+		return "the contract"
+	}
+	return recipient.Hex()
+}
+
+// isSelfRecipient returns whether a recipient is the caller or the contract itself, which the contracts substitute
+// for the MsgSender and AddressThis placeholders
+func isSelfRecipient(recipient common.Address) bool {
+	return recipient == MsgSender || recipient == AddressThis
+}
+
+func describeFee(feeBips *big.Int, feeRecipient common.Address) string {
+	if feeBips == nil {
+		return ""
+	}
+	return fmt.Sprintf(", taking a %s%% fee for %s", decimal.NewFromBigInt(feeBips, -2), feeRecipient.Hex())
+}
+
+func formatAmount(amount *big.Int, decimals uint) string {
+	return decimal.NewFromBigInt(amount, -int32(decimals)).String()
+}
+
+func formatMaxAmount(amount *big.Int) string {
+	if amount.Cmp(MaxUint128) == 0 {
+		return "all"
+	}
+	return amount.String()
+}
+
+func formatFee(fee *big.Int) string {
+	return decimal.NewFromBigInt(fee, -4).String() + "%"
+}
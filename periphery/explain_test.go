@@ -0,0 +1,148 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainMethodParameters(t *testing.T) {
+	pool_0_1 := makePool(token0, token1)
+	pool_1_weth := makePool(token1, weth)
+	sender := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	feeRecipient := common.HexToAddress("0x0000000000000000000000000000000000000009")
+
+	// a SwapRouter02 swap to ETH with a fee
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1, pool_1_weth}, token0, ether)
+	trade, _ := entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	params, err := SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: core.NewPercent(big.NewInt(1), big.NewInt(100)),
+		Recipient:         sender,
+		Deadline:          big.NewInt(1800),
+		Fee:               &FeeOptions{Fee: core.NewPercent(big.NewInt(5), big.NewInt(1000)), Recipient: feeRecipient},
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanation, err := ExplainMethodParameters(params, &ExplainOptions{
+		Sender: sender,
+		Tokens: []*core.Token{token0, token1, weth},
+		Now:    time.Unix(0, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ""+
+		"1. swap exactly 0.0000000000000001 t0 for at least 0.000000000000000095 WETH via t0 -0.3%-> t1 -0.3%-> WETH to the contract\n"+
+		"2. unwrap at least 0.000000000000000095 WETH and send the ETH to the sender, taking a 0.5% fee for 0x0000000000000000000000000000000000000009\n"+
+		"value: 0 ETH\n", explanation.String())
+	assert.Empty(t, explanation.Risks)
+
+	// a SwapRouter swap with no minimum out to another account, long before the deadline
+	calldata, _ := GetABI(swapRouterABI).Pack("exactInputSingle", &ExactInputSingleParams{
+		TokenIn:           weth.Address,
+		TokenOut:          token0.Address,
+		Fee:               big.NewInt(500),
+		Recipient:         recipientT,
+		Deadline:          big.NewInt(7200),
+		AmountIn:          big.NewInt(1_500_000_000_000_000_000),
+		AmountOutMinimum:  big.NewInt(0),
+		SqrtPriceLimitX96: big.NewInt(0),
+	})
+	explanation, err = ExplainMethodParameters(&utils.MethodParameters{Calldata: calldata, Value: big.NewInt(1_500_000_000_000_000_000)}, &ExplainOptions{
+		Sender: sender,
+		Tokens: []*core.Token{token0, weth},
+		Now:    time.Unix(0, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "swap exactly 1.5 WETH for at least 0 t0 via WETH -0.05%-> t0 to 0x0000000000000000000000000000000000000003 until 1970-01-01T02:00:00Z", explanation.Actions[0].Description)
+	assert.Equal(t, "1.5 ETH", explanation.Value)
+	var kinds []RiskKind
+	for _, risk := range explanation.Risks {
+		kinds = append(kinds, risk.Kind)
+		assert.Equal(t, 0, risk.Action)
+	}
+	assert.Equal(t, []RiskKind{RiskZeroMinimumOut, RiskForeignRecipient, RiskFarDeadline}, kinds)
+}
+
+func TestExplainMethodParametersLiquidity(t *testing.T) {
+	pos, _ := entities.NewPosition(pool01T, big.NewInt(100), -60, 60)
+	params, err := RemoveCallParameters(pos, &RemoveLiquidityOptions{
+		TokenID:             tokenIDT,
+		LiquidityPercentage: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Deadline:            deadlineT,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: core.FromRawAmount(token0T, big.NewInt(0)),
+			ExpectedCurrencyOwed1: core.FromRawAmount(token1T, big.NewInt(0)),
+			Recipient:             recipientT,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanation, err := ExplainMethodParameters(params, &ExplainOptions{Now: time.Unix(0, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ""+
+		"1. remove 100 liquidity from position #1 for at least 0 of token0 and 0 of token1 until 1970-01-01T00:02:03Z\n"+
+		"2. collect all of token0 and all of token1 owed to position #1 to 0x0000000000000000000000000000000000000003\n"+
+		"value: 0 ETH\n"+
+		"risk in action 1: liquidity is removed without a minimum amount of either token\n", explanation.String())
+
+	_, err = ExplainMethodParameters(&utils.MethodParameters{Calldata: []byte{1, 2, 3, 4}, Value: big.NewInt(0)}, nil)
+	assert.Equal(t, ErrUnknownMethod, err)
+}
+
+func TestExplainMethodParametersPermitsAndRecipients(t *testing.T) {
+	var r, s [32]byte
+	standard, _ := EncodePermit(weth, &PermitOptions{StandardPermitArguments: &StandardPermitArguments{
+		V: 27, R: r, S: s, Amount: big.NewInt(1_500_000_000_000_000_000), Deadline: core.MaxUint256,
+	}})
+	allowed, _ := EncodePermit(token0, &PermitOptions{AllowedPermitArguments: &AllowedPermitArguments{
+		V: 27, R: r, S: s, Nonce: big.NewInt(0), Expiry: big.NewInt(0),
+	}})
+	unwrap, _ := EncodeUnwrapWETH9(big.NewInt(0), common.Address{}, nil)
+	collect, _ := GetABI(nonFungiblePositionManagerABI).Pack("collect", &CollectParams{
+		TokenId: big.NewInt(1), Recipient: common.Address{}, Amount0Max: MaxUint128, Amount1Max: MaxUint128,
+	})
+	calldata, err := EncodeMulticallWithDeadline(core.MaxUint256, [][]byte{standard, allowed, unwrap, collect})
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanation, err := ExplainMethodParameters(&utils.MethodParameters{Calldata: calldata, Value: big.NewInt(0)}, &ExplainOptions{
+		Tokens: []*core.Token{weth},
+		Now:    time.Unix(0, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ""+
+		"1. permit the contract to spend 1.5 of the sender's WETH with no deadline (max uint256)\n"+
+		"2. permit the contract to spend an unlimited amount of the sender's "+token0.Address.Hex()+" with no expiry\n"+
+		"3. unwrap at least 0 WETH and send the ETH to 0x0000000000000000000000000000000000000000\n"+
+		"4. collect all of token0 and all of token1 owed to position #1 to the contract\n"+
+		"value: 0 ETH\n"+
+		"risk in action 1: the transaction has no deadline (max uint256)\n"+
+		"risk in action 1: the permit has no deadline (max uint256)\n"+
+		"risk in action 2: the permit never expires\n"+
+		"risk in action 3: the recipient is the zero address, so the tokens are lost\n", explanation.String())
+	assert.Equal(t, RiskForeignRecipient, explanation.Risks[3].Kind)
+
+	// a permit with an unknown token still names it once
+	explanation, err = ExplainMethodParameters(&utils.MethodParameters{Calldata: standard, Value: big.NewInt(0)}, &ExplainOptions{Now: time.Unix(0, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "permit the contract to spend 1500000000000000000 of the sender's "+weth.Address.Hex()+" with no deadline (max uint256)", explanation.Actions[0].Description)
+}
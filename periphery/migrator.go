@@ -0,0 +1,133 @@
+package periphery
+
+import (
+	_ "embed"
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed contracts/V3Migrator.sol/V3Migrator.json
+var migratorABI []byte
+
+var ErrInvalidMigratePercentage = errors.New("invalid migrate percentage")
+
+// Options for producing the calldata to migrate liquidity from a V2 pair to a V3 position
+type MigrateOptions struct {
+	Pair                common.Address // The V2 pair to migrate liquidity out of
+	LiquidityToMigrate  *big.Int       // The amount of the pair's liquidity tokens to burn
+	PercentageToMigrate *core.Percent  // The whole percentage of the burned tokens to add to the V3 position, the rest is refunded
+	SlippageTolerance   *core.Percent  // How much the pool price is allowed to move
+	Recipient           common.Address // The account that should receive the position NFT and the refunds
+	Deadline            *big.Int       // When the transaction expires, in epoch seconds
+	RefundAsETH         bool           // Whether a WETH refund should be unwrapped to ETH
+	CreatePool          bool           // Whether to create and initialize the pool at its current price, if it does not exist
+	PairPermit          *PermitOptions // The optional permit of the pair's liquidity tokens for the migrator
+}
+
+type MigrateParams struct {
+	Pair                common.Address
+	LiquidityToMigrate  *big.Int
+	PercentageToMigrate uint8
+	Token0              common.Address
+	Token1              common.Address
+	Fee                 *big.Int
+	TickLower           *big.Int
+	TickUpper           *big.Int
+	Amount0Min          *big.Int
+	Amount1Min          *big.Int
+	Recipient           common.Address
+	Deadline            *big.Int
+	RefundAsETH         bool
+}
+
+/**
+ * Returns the amounts of the pair's tokens that burning V2 liquidity is worth
+ * @param liquidity The amount of liquidity tokens to burn
+ * @param totalSupply The total supply of the pair's liquidity tokens
+ * @param reserve0 The pair's reserve of token0
+ * @param reserve1 The pair's reserve of token1
+ */
+func V2BurnAmounts(liquidity, totalSupply, reserve0, reserve1 *big.Int) (amount0, amount1 *big.Int) {
+	amount0 = new(big.Int).Div(new(big.Int).Mul(liquidity, reserve0), totalSupply)
+	amount1 = new(big.Int).Div(new(big.Int).Mul(liquidity, reserve1), totalSupply)
+	return amount0, amount1
+}
+
+/**
+ * Produces the calldata for migrating liquidity from a V2 pair into a new V3 position
+ * @param position The position to mint, with the liquidity the migrated share of the burned tokens is expected to add,
+ * e.g. from FromAmounts with the V2BurnAmounts scaled by the percentage to migrate
+ * @param options Options for the migration
+ * @returns The call parameters for the migrator
+ */
+func MigrateCallParameters(position *entities.Position, opts *MigrateOptions) (*utils.MethodParameters, error) {
+	if position.Liquidity.Cmp(constants.Zero) <= 0 {
+		return nil, ErrZeroLiquidity
+	}
+	percentage := opts.PercentageToMigrate.Multiply(core.NewPercent(big.NewInt(100), constants.One))
+	if percentage.Remainder().Numerator.Sign() != 0 || percentage.Quotient().Sign() <= 0 || percentage.Quotient().Cmp(big.NewInt(100)) > 0 {
+		return nil, ErrInvalidMigratePercentage
+	}
+
+	var calldatas [][]byte
+	abi := GetABI(migratorABI)
+
+	// permit the migrator to pull the liquidity tokens
+	if opts.PairPermit != nil {
+		pair := core.NewToken(position.Pool.Token0.ChainId(), opts.Pair, 18, "UNI-V2", "Uniswap V2")
+		calldata, err := EncodePermit(pair, opts.PairPermit)
+		if err != nil {
+			return nil, err
+		}
+		calldatas = append(calldatas, calldata)
+	}
+
+	pool := position.Pool
+	if opts.CreatePool {
+		calldata, err := abi.Pack("createAndInitializePoolIfNecessary", pool.Token0.Address, pool.Token1.Address, big.NewInt(int64(pool.Fee)), pool.SqrtRatioX96)
+		if err != nil {
+			return nil, err
+		}
+		calldatas = append(calldatas, calldata)
+	}
+
+	// adjust for slippage
+	amount0Min, amount1Min, err := position.MintAmountsWithSlippage(opts.SlippageTolerance)
+	if err != nil {
+		return nil, err
+	}
+	calldata, err := abi.Pack("migrate", &MigrateParams{
+		Pair:                opts.Pair,
+		LiquidityToMigrate:  opts.LiquidityToMigrate,
+		PercentageToMigrate: uint8(percentage.Quotient().Uint64()),
+		Token0:              pool.Token0.Address,
+		Token1:              pool.Token1.Address,
+		Fee:                 big.NewInt(int64(pool.Fee)),
+		TickLower:           big.NewInt(int64(position.TickLower)),
+		TickUpper:           big.NewInt(int64(position.TickUpper)),
+		Amount0Min:          amount0Min,
+		Amount1Min:          amount1Min,
+		Recipient:           opts.Recipient,
+		Deadline:            opts.Deadline,
+		RefundAsETH:         opts.RefundAsETH,
+	})
+	if err != nil {
+		return nil, err
+	}
+	calldatas = append(calldatas, calldata)
+
+	data, err := EncodeMulticall(calldatas)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.MethodParameters{
+		Calldata: data,
+		Value:    constants.Zero,
+	}, nil
+}
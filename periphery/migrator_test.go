@@ -0,0 +1,109 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV2BurnAmounts(t *testing.T) {
+	amount0, amount1 := V2BurnAmounts(big.NewInt(10), big.NewInt(1000), big.NewInt(5000), big.NewInt(20001))
+	assert.Equal(t, big.NewInt(50), amount0)
+	assert.Equal(t, big.NewInt(200), amount1)
+}
+
+func TestMigrateCallParameters(t *testing.T) {
+	pair := common.HexToAddress("0x0000000000000000000000000000000000000009")
+	spacing := constants.TickSpacings[constants.FeeMedium]
+	amount0, amount1 := V2BurnAmounts(big.NewInt(1000), big.NewInt(10000), big.NewInt(1000000), big.NewInt(1000000))
+	position, err := entities.FromAmounts(pool01T, -spacing, spacing, amount0, amount1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &MigrateOptions{
+		Pair:                pair,
+		LiquidityToMigrate:  big.NewInt(1000),
+		PercentageToMigrate: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Recipient:           recipientT,
+		Deadline:            deadlineT,
+	}
+
+	// a bare migrate call
+	params, err := MigrateCallParameters(position, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x00", utils.ToHex(params.Value))
+	contract := GetABI(migratorABI)
+	method, err := contract.MethodById(params.Calldata[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "migrate", method.Name)
+	args, err := method.Inputs.Unpack(params.Calldata[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrate := *abi.ConvertType(args[0], new(MigrateParams)).(*MigrateParams)
+	amount0Min, amount1Min, _ := position.MintAmountsWithSlippage(slippageToleranceT)
+	assert.Equal(t, pair, migrate.Pair)
+	assert.Equal(t, big.NewInt(1000), migrate.LiquidityToMigrate)
+	assert.Equal(t, uint8(100), migrate.PercentageToMigrate)
+	assert.Equal(t, token0T.Address, migrate.Token0)
+	assert.Equal(t, token1T.Address, migrate.Token1)
+	assert.Equal(t, big.NewInt(3000), migrate.Fee)
+	assert.Equal(t, big.NewInt(int64(-spacing)), migrate.TickLower)
+	assert.Equal(t, big.NewInt(int64(spacing)), migrate.TickUpper)
+	assert.Equal(t, 0, amount0Min.Cmp(migrate.Amount0Min))
+	assert.Equal(t, 0, amount1Min.Cmp(migrate.Amount1Min))
+	assert.Equal(t, recipientT, migrate.Recipient)
+	assert.Equal(t, deadlineT, migrate.Deadline)
+	assert.False(t, migrate.RefundAsETH)
+
+	// with a permit of the liquidity tokens and pool creation
+	opts.PercentageToMigrate = core.NewPercent(big.NewInt(50), big.NewInt(100))
+	opts.CreatePool = true
+	opts.RefundAsETH = true
+	opts.PairPermit = &PermitOptions{
+		StandardPermitArguments: &StandardPermitArguments{V: 27, Amount: big.NewInt(1000), Deadline: deadlineT},
+	}
+	params, err = MigrateCallParameters(position, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := decodeMulticallCalls(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(calls))
+	assert.Equal(t, "0xf3995c670000000000000000000000000000000000000000000000000000000000000009", hexutil.Encode(calls[0][:36]))
+	assert.Equal(t, "0x13ead562", hexutil.Encode(calls[1][:4]))
+	args, _ = contract.Methods["migrate"].Inputs.Unpack(calls[2][4:])
+	migrate = *abi.ConvertType(args[0], new(MigrateParams)).(*MigrateParams)
+	assert.Equal(t, uint8(50), migrate.PercentageToMigrate)
+	assert.True(t, migrate.RefundAsETH)
+
+	// the percentage must be a whole percent of at most 100
+	for _, percentage := range []*core.Percent{
+		core.NewPercent(big.NewInt(0), big.NewInt(1)),
+		core.NewPercent(big.NewInt(101), big.NewInt(100)),
+		core.NewPercent(big.NewInt(1), big.NewInt(1000)),
+	} {
+		opts.PercentageToMigrate = percentage
+		_, err = MigrateCallParameters(position, opts)
+		assert.Equal(t, ErrInvalidMigratePercentage, err)
+	}
+
+	position, _ = entities.NewPosition(pool01T, big.NewInt(0), -spacing, spacing)
+	_, err = MigrateCallParameters(position, opts)
+	assert.Equal(t, ErrZeroLiquidity, err)
+}
@@ -27,6 +27,16 @@ func EncodeMulticall(calldatas [][]byte) ([]byte, error) {
 	return b, nil
 }
 
+// DecodeMulticall decodes the results of a multicall, in the order of the calls
+func DecodeMulticall(data []byte) ([][]byte, error) {
+	abi := GetABI(multicallABI)
+	var results [][]byte
+	if err := abi.UnpackIntoInterface(&results, "multicall", data); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func GetABI(abi []byte) abi.ABI {
 	var wabi WrappedABI
 	err := json.Unmarshal(abi, &wabi)
@@ -23,3 +23,15 @@ func TestEncodeMulticall(t *testing.T) {
 		"0xac9650d800000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000000000000000000020aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa0000000000000000000000000000000000000000000000000000000000000020bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
 		hexutil.Encode(b))
 }
+
+func TestDecodeMulticall(t *testing.T) {
+	results := [][]byte{hexutil.MustDecode("0xaaaa"), hexutil.MustDecode("0x")}
+	data, err := GetABI(multicallABI).Methods["multicall"].Outputs.Pack(results)
+	assert.NoError(t, err)
+	decoded, err := DecodeMulticall(data)
+	assert.NoError(t, err)
+	assert.Equal(t, results, decoded)
+
+	_, err = DecodeMulticall(hexutil.MustDecode("0x01"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package periphery
+
+import (
+	"math/big"
+
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var nftPermitTypeHash = crypto.Keccak256([]byte("Permit(address spender,uint256 tokenId,uint256 nonce,uint256 deadline)"))
+
+// NFTPermit is a permit for a spender to operate a position NFT, signed by its owner or an approved operator
+type NFTPermit struct {
+	Domain   *utils.EIP712Domain // The domain of the position manager, see NewNFTPermitDomain
+	Spender  common.Address      // The account allowed to operate the NFT
+	TokenID  *big.Int            // The ID of the NFT
+	Nonce    *big.Int            // The current nonce of the position, from positions(tokenId)
+	Deadline *big.Int            // When the permit expires, in epoch seconds
+}
+
+/**
+ * Returns the EIP-712 domain of a position manager's permits
+ * @param chainID The chain the position manager is deployed on
+ * @param manager The address of the position manager
+ */
+func NewNFTPermitDomain(chainID *big.Int, manager common.Address) *utils.EIP712Domain {
+	return &utils.EIP712Domain{
+		Name:              "Uniswap V3 Positions NFT-V1",
+		Version:           "1",
+		ChainID:           chainID,
+		VerifyingContract: manager,
+	}
+}
+
+// Hash returns the EIP-712 struct hash of the permit
+func (p *NFTPermit) Hash() []byte {
+	return crypto.Keccak256(
+		nftPermitTypeHash,
+		utils.EncodeAddress(p.Spender),
+		utils.EncodeUint256(p.TokenID),
+		utils.EncodeUint256(p.Nonce),
+		utils.EncodeUint256(p.Deadline),
+	)
+}
+
+// TypedDataHash returns the digest the owner signs
+func (p *NFTPermit) TypedDataHash() []byte {
+	return utils.TypedDataHash(p.Domain, p.Hash())
+}
+
+/**
+ * Signs a permit to operate a position NFT
+ * @param signer The owner of the NFT or an approved operator
+ * @param permit The permit to sign
+ * @returns The permit options to pass as the permit of RemoveLiquidityOptions
+ */
+func SignNFTPermit(signer utils.Signer, permit *NFTPermit) (*NFTPermitOptions, error) {
+	signature, err := signer.SignHash(permit.TypedDataHash())
+	if err != nil {
+		return nil, err
+	}
+	v, r, s, err := utils.SplitSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return &NFTPermitOptions{
+		V:        v,
+		R:        r,
+		S:        s,
+		Deadline: permit.Deadline,
+		Spender:  permit.Spender,
+	}, nil
+}
+
+/**
+ * Recovers the account that signed a permit to operate a position NFT
+ * @param permit The permit that was signed
+ * @param options The signature of the permit
+ * @returns The address of the signer, which must own or be approved for the NFT
+ */
+func RecoverNFTPermitSigner(permit *NFTPermit, options *NFTPermitOptions) (common.Address, error) {
+	signature := append(append(append([]byte{}, options.R[:]...), options.S[:]...), options.V)
+	return utils.RecoverSigner(permit.TypedDataHash(), signature)
+}
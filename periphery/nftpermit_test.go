@@ -0,0 +1,105 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/internal/testutil"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignNFTPermit(t *testing.T) {
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	operator := common.HexToAddress("0x0000000000000000000000000000000000000005")
+	permit := &NFTPermit{
+		Domain:   NewNFTPermitDomain(big.NewInt(1), constants.NonfungiblePositionManagerAddress),
+		Spender:  operator,
+		TokenID:  tokenIDT,
+		Nonce:    big.NewInt(0),
+		Deadline: deadlineT,
+	}
+
+	expected := testutil.TypedDataHash(t, apitypes.TypedDataDomain{
+		Name:              "Uniswap V3 Positions NFT-V1",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: constants.NonfungiblePositionManagerAddress.Hex(),
+	}, "Permit", apitypes.Types{
+		"Permit": {
+			{Name: "spender", Type: "address"},
+			{Name: "tokenId", Type: "uint256"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+	}, apitypes.TypedDataMessage{
+		"spender":  operator.Hex(),
+		"tokenId":  tokenIDT.String(),
+		"nonce":    "0",
+		"deadline": deadlineT.String(),
+	})
+	assert.Equal(t, expected, permit.TypedDataHash())
+
+	options, err := SignNFTPermit(signer, permit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, operator, options.Spender)
+	assert.Equal(t, deadlineT, options.Deadline)
+	owner, err := RecoverNFTPermitSigner(permit, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), owner)
+
+	// the permit is the first call of the exit
+	pos, err := entities.NewPosition(pool01T, big.NewInt(100), -constants.TickSpacings[constants.FeeMedium], constants.TickSpacings[constants.FeeMedium])
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := RemoveCallParameters(pos, &RemoveLiquidityOptions{
+		TokenID:             tokenIDT,
+		LiquidityPercentage: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Deadline:            deadlineT,
+		Permit:              options,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: core.FromRawAmount(token0T, big.NewInt(0)),
+			ExpectedCurrencyOwed1: core.FromRawAmount(token1T, big.NewInt(0)),
+			Recipient:             recipientT,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls, err := decodeMulticallCalls(params.Calldata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(calls))
+	expectedCalldata := "0x7ac2ff7b" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"000000000000000000000000000000000000000000000000000000000000007b" +
+		hexutil.Encode(common.LeftPadBytes([]byte{options.V}, 32))[2:] +
+		hexutil.Encode(options.R[:])[2:] +
+		hexutil.Encode(options.S[:])[2:]
+	assert.Equal(t, expectedCalldata, hexutil.Encode(calls[0]))
+}
+
+// decodeMulticallCalls returns the calls of a multicall(bytes[])
+func decodeMulticallCalls(data []byte) ([][]byte, error) {
+	contract := GetABI(multicallABI)
+	args, err := contract.Methods["multicall"].Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return args[0].([][]byte), nil
+}
@@ -85,11 +85,11 @@ type CollectOptions struct {
 }
 
 type NFTPermitOptions struct {
-	V        uint
-	R        string
-	S        string
-	Deadline *big.Int
-	Spender  string
+	V        uint8
+	R        [32]byte
+	S        [32]byte
+	Deadline *big.Int       // When the permit expires, in epoch seconds
+	Spender  common.Address // The account allowed to operate the NFT
 }
 
 // Options for producing the calldata to exit a position
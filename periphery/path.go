@@ -0,0 +1,107 @@
+package periphery
+
+import (
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrPathTooShort      = errors.New("path too short")
+	ErrInvalidPathLength = errors.New("invalid path length")
+	ErrInvalidPathFee    = errors.New("invalid path fee")
+	ErrPoolNotFound      = errors.New("pool not found")
+	ErrPathPoolMismatch  = errors.New("pool does not match path")
+)
+
+const (
+	pathAddrSize   = 20                            // The length of the bytes encoded address
+	pathFeeSize    = 3                             // The length of the bytes encoded fee
+	pathNextOffset = pathAddrSize + pathFeeSize    // The offset of a single token address and pool fee
+	pathPopOffset  = pathNextOffset + pathAddrSize // The offset of an encoded pool key
+)
+
+// PoolLookup returns the pool for the given pair of tokens and fee tier, in any token order.
+type PoolLookup func(tokenA, tokenB common.Address, fee constants.FeeAmount) (*entities.Pool, error)
+
+/**
+ * Decodes a packed path, as produced by EncodeRouteToPath, into its tokens and fees
+ * @param path the packed path, i.e. token, fee, token, fee, ..., token
+ * @returns The token addresses, in path order
+ * @returns The fee of the pool between each pair of consecutive tokens
+ */
+func DecodePath(path []byte) ([]common.Address, []constants.FeeAmount, error) {
+	if len(path) < pathPopOffset {
+		return nil, nil, ErrPathTooShort
+	}
+	if (len(path)-pathAddrSize)%pathNextOffset != 0 {
+		return nil, nil, ErrInvalidPathLength
+	}
+
+	numPools := (len(path) - pathAddrSize) / pathNextOffset
+	tokens := make([]common.Address, 0, numPools+1)
+	fees := make([]constants.FeeAmount, 0, numPools)
+	for i := 0; i < numPools; i++ {
+		offset := i * pathNextOffset
+		fee := constants.FeeAmount(new(big.Int).SetBytes(path[offset+pathAddrSize : offset+pathNextOffset]).Uint64())
+		if fee >= constants.FeeMax {
+			return nil, nil, ErrInvalidPathFee
+		}
+		tokens = append(tokens, common.BytesToAddress(path[offset:offset+pathAddrSize]))
+		fees = append(fees, fee)
+	}
+	tokens = append(tokens, common.BytesToAddress(path[len(path)-pathAddrSize:]))
+	return tokens, fees, nil
+}
+
+/**
+ * Rebuilds the route encoded in a packed path
+ * @param path the packed path
+ * @param exactOutput whether the path is encoded in reverse, as it is for exact output swaps
+ * @param lookup resolves the pool for each hop of the path
+ * @returns The route, from the input token to the output token
+ */
+func DecodePathToRoute(path []byte, exactOutput bool, lookup PoolLookup) (*entities.Route, error) {
+	tokens, fees, err := DecodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if exactOutput {
+		reverse(tokens)
+		reverse(fees)
+	}
+
+	pools := make([]*entities.Pool, len(fees))
+	for i, fee := range fees {
+		pool, err := lookup(tokens[i], tokens[i+1], fee)
+		if err != nil {
+			return nil, err
+		}
+		if pool == nil {
+			return nil, ErrPoolNotFound
+		}
+		if pool.Fee != fee || !poolHasToken(pool, tokens[i]) || !poolHasToken(pool, tokens[i+1]) {
+			return nil, ErrPathPoolMismatch
+		}
+		pools[i] = pool
+	}
+
+	input := poolToken(pools[0], tokens[0])
+	output := poolToken(pools[len(pools)-1], tokens[len(tokens)-1])
+	return entities.NewRoute(pools, input, output)
+}
+
+func poolHasToken(pool *entities.Pool, address common.Address) bool {
+	return pool.Token0.Address == address || pool.Token1.Address == address
+}
+
+func poolToken(pool *entities.Pool, address common.Address) *core.Token {
+	if pool.Token0.Address == address {
+		return pool.Token0
+	}
+	return pool.Token1
+}
@@ -0,0 +1,96 @@
+package periphery
+
+import (
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPoolLookup(pools ...*entities.Pool) PoolLookup {
+	return func(tokenA, tokenB common.Address, fee constants.FeeAmount) (*entities.Pool, error) {
+		for _, pool := range pools {
+			if pool.Fee == fee && poolHasToken(pool, tokenA) && poolHasToken(pool, tokenB) {
+				return pool, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestDecodePath(t *testing.T) {
+	// decodes a single hop path
+	p, _ := EncodeRouteToPath(route_0_1, false)
+	tokens, fees, err := DecodePath(p)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Address{token0.Address, token1.Address}, tokens)
+	assert.Equal(t, []constants.FeeAmount{constants.FeeMedium}, fees)
+
+	// decodes a multihop path
+	p, _ = EncodeRouteToPath(route_0_1_2, false)
+	tokens, fees, err = DecodePath(p)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Address{token0.Address, token1.Address, token2.Address}, tokens)
+	assert.Equal(t, []constants.FeeAmount{constants.FeeMedium, constants.FeeLow}, fees)
+
+	// decodes a reversed multihop path as is
+	p, _ = EncodeRouteToPath(route_0_1_2, true)
+	tokens, fees, err = DecodePath(p)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Address{token2.Address, token1.Address, token0.Address}, tokens)
+	assert.Equal(t, []constants.FeeAmount{constants.FeeLow, constants.FeeMedium}, fees)
+
+	// fails for a path without a pool
+	_, _, err = DecodePath(token0.Address.Bytes())
+	assert.ErrorIs(t, err, ErrPathTooShort)
+
+	// fails for a truncated path
+	p, _ = EncodeRouteToPath(route_0_1_2, false)
+	_, _, err = DecodePath(p[:len(p)-1])
+	assert.ErrorIs(t, err, ErrInvalidPathLength)
+
+	// fails for a fee above the maximum
+	_, _, err = DecodePath(hexutil.MustDecode("0x0000000000000000000000000000000000000001ffffff0000000000000000000000000000000000000002"))
+	assert.ErrorIs(t, err, ErrInvalidPathFee)
+}
+
+func TestDecodePathToRoute(t *testing.T) {
+	lookup := testPoolLookup(pool_0_1_medium, pool_1_2_low, pool_0_weth)
+
+	// rebuilds an exact input route
+	p, _ := EncodeRouteToPath(route_0_1_2, false)
+	route, err := DecodePathToRoute(p, false, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Pool{pool_0_1_medium, pool_1_2_low}, route.Pools)
+	assert.True(t, route.Input.Equal(token0))
+	assert.True(t, route.Output.Equal(token2))
+
+	// rebuilds an exact output route
+	p, _ = EncodeRouteToPath(route_0_1_2, true)
+	route, err = DecodePathToRoute(p, true, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Pool{pool_0_1_medium, pool_1_2_low}, route.Pools)
+	assert.True(t, route.Input.Equal(token0))
+	assert.True(t, route.Output.Equal(token2))
+
+	// round trips a route through weth
+	p, _ = EncodeRouteToPath(route_weth_0, false)
+	route, err = DecodePathToRoute(p, false, lookup)
+	assert.NoError(t, err)
+	assert.True(t, route.Input.Equal(weth))
+
+	// fails when a pool is unknown
+	p, _ = EncodeRouteToPath(route_0_1_weth, false)
+	_, err = DecodePathToRoute(p, false, lookup)
+	assert.ErrorIs(t, err, ErrPoolNotFound)
+
+	// fails when the lookup returns a pool for another pair
+	p, _ = EncodeRouteToPath(route_0_1, false)
+	_, err = DecodePathToRoute(p, false, func(tokenA, tokenB common.Address, fee constants.FeeAmount) (*entities.Pool, error) {
+		return pool_1_2_low, nil
+	})
+	assert.ErrorIs(t, err, ErrPathPoolMismatch)
+}
@@ -0,0 +1,225 @@
+package periphery
+
+import (
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrNoPrice = errors.New("no pools to price token")
+
+// A position held as an NFT of a position manager
+type PortfolioPosition struct {
+	TokenID  *big.Int           // The ID of the token of the position
+	Manager  common.Address     // The position manager holding the position
+	Position *entities.Position // The position, with the tokens owed to it and its fee growth if known
+
+	// The current fee growth inside the position's range, e.g. from utils.GetFeeGrowthInside with the pool's ticks.
+	// If nil, only the tokens owed as of the position's last update are counted as its fees.
+	FeeGrowthInside0X128 *big.Int
+	FeeGrowthInside1X128 *big.Int
+}
+
+// Portfolio is a set of positions across pools and position managers
+type Portfolio struct {
+	Positions []*PortfolioPosition
+}
+
+func NewPortfolio(positions ...*PortfolioPosition) *Portfolio {
+	return &Portfolio{Positions: positions}
+}
+
+/**
+ * Returns the total amounts of each token that the positions could be burned for at their pools' current prices
+ * @returns One amount per token, in the order the tokens first appear in the positions
+ */
+func (p *Portfolio) Exposure() ([]*core.CurrencyAmount, error) {
+	var amounts []*core.CurrencyAmount
+	for _, pp := range p.Positions {
+		amount0, err := pp.Position.Amount0()
+		if err != nil {
+			return nil, err
+		}
+		amount1, err := pp.Position.Amount1()
+		if err != nil {
+			return nil, err
+		}
+		amounts = addAmount(addAmount(amounts, amount0), amount1)
+	}
+	return amounts, nil
+}
+
+/**
+ * Returns the total fees owed to the positions, including those earned since each position was last updated on chain
+ * for the positions whose current fee growth inside their range is given
+ * @returns One amount per token, in the order the tokens first appear in the positions
+ */
+func (p *Portfolio) UncollectedFees() []*core.CurrencyAmount {
+	var amounts []*core.CurrencyAmount
+	for _, pp := range p.Positions {
+		fees0, fees1 := pp.feesOwed()
+		amounts = addAmount(addAmount(amounts, fees0), fees1)
+	}
+	return amounts
+}
+
+// InRange returns the positions whose range contains their pool's current tick, i.e. that are earning fees
+func (p *Portfolio) InRange() []*PortfolioPosition {
+	var positions []*PortfolioPosition
+	for _, pp := range p.Positions {
+		if isInRange(pp.Position) {
+			positions = append(positions, pp)
+		}
+	}
+	return positions
+}
+
+// OutOfRange returns the positions whose range does not contain their pool's current tick
+func (p *Portfolio) OutOfRange() []*PortfolioPosition {
+	var positions []*PortfolioPosition
+	for _, pp := range p.Positions {
+		if !isInRange(pp.Position) {
+			positions = append(positions, pp)
+		}
+	}
+	return positions
+}
+
+/**
+ * Returns the total value of the positions and their uncollected fees in a numeraire. Each token is priced at the
+ * mid price of the fewest pools connecting it to the numeraire, among the pools of the positions and the pricing pools.
+ * @param numeraire The token to value the portfolio in
+ * @param pricingPools Additional pools to price tokens with
+ * @returns The value of the portfolio, or ErrNoPrice if a token cannot be priced
+ */
+func (p *Portfolio) Value(numeraire *core.Token, pricingPools ...*entities.Pool) (*core.CurrencyAmount, error) {
+	pools := append([]*entities.Pool{}, pricingPools...)
+	for _, pp := range p.Positions {
+		pools = append(pools, pp.Position.Pool)
+	}
+	exposure, err := p.Exposure()
+	if err != nil {
+		return nil, err
+	}
+
+	total := core.FromRawAmount(numeraire, constants.Zero)
+	for _, amount := range append(exposure, p.UncollectedFees()...) {
+		token := amount.Currency.Wrapped()
+		if token.Equal(numeraire) {
+			total = total.Add(amount)
+			continue
+		}
+		route := shortestRoute(pools, token, numeraire)
+		if route == nil {
+			return nil, ErrNoPrice
+		}
+		price, err := route.MidPrice()
+		if err != nil {
+			return nil, err
+		}
+		value, err := price.Quote(amount)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(value)
+	}
+	return total, nil
+}
+
+/**
+ * Produces the calldata for collecting the fees and tokens owed to every position, batched into one multicall per
+ * position manager
+ * @param recipient The account that should receive the tokens
+ * @returns The call parameters for each position manager holding positions
+ */
+func (p *Portfolio) CollectAllCallParameters(recipient common.Address) (map[common.Address]*utils.MethodParameters, error) {
+	calldatas := make(map[common.Address][][]byte)
+	for _, pp := range p.Positions {
+		fees0, fees1 := pp.feesOwed()
+		collect, err := encodeCollect(&CollectOptions{
+			TokenID:               pp.TokenID,
+			ExpectedCurrencyOwed0: fees0,
+			ExpectedCurrencyOwed1: fees1,
+			Recipient:             recipient,
+		})
+		if err != nil {
+			return nil, err
+		}
+		calldatas[pp.Manager] = append(calldatas[pp.Manager], collect...)
+	}
+
+	params := make(map[common.Address]*utils.MethodParameters, len(calldatas))
+	for manager, calls := range calldatas {
+		data, err := EncodeMulticall(calls)
+		if err != nil {
+			return nil, err
+		}
+		params[manager] = &utils.MethodParameters{
+			Calldata: data,
+			Value:    constants.Zero,
+		}
+	}
+	return params, nil
+}
+
+func (pp *PortfolioPosition) feesOwed() (*core.CurrencyAmount, *core.CurrencyAmount) {
+	return pp.Position.FeesOwed(pp.FeeGrowthInside0X128, pp.FeeGrowthInside1X128)
+}
+
+func isInRange(position *entities.Position) bool {
+	tick := position.Pool.TickCurrent
+	return position.TickLower <= tick && tick < position.TickUpper
+}
+
+// addAmount adds an amount to the amount of the same token in a list, or appends it
+func addAmount(amounts []*core.CurrencyAmount, amount *core.CurrencyAmount) []*core.CurrencyAmount {
+	for i, a := range amounts {
+		if a.Currency.Equal(amount.Currency) {
+			amounts[i] = a.Add(amount)
+			return amounts
+		}
+	}
+	return append(amounts, amount)
+}
+
+// shortestRoute returns a route through the fewest pools from one token to another, or nil if they are not connected
+func shortestRoute(pools []*entities.Pool, from, to *core.Token) *entities.Route {
+	type step struct {
+		token *core.Token
+		pools []*entities.Pool
+	}
+	visited := map[common.Address]bool{from.Address: true}
+	queue := []step{{token: from}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, pool := range pools {
+			if !pool.InvolvesToken(current.token) {
+				continue
+			}
+			next := pool.Token0
+			if next.Equal(current.token) {
+				next = pool.Token1
+			}
+			if visited[next.Address] {
+				continue
+			}
+			visited[next.Address] = true
+			path := append(append([]*entities.Pool{}, current.pools...), pool)
+			if next.Equal(to) {
+				route, err := entities.NewRoute(path, from, to)
+				if err != nil {
+					return nil
+				}
+				return route
+			}
+			queue = append(queue, step{token: next, pools: path})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortfolio(t *testing.T) {
+	managerA := common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88")
+	managerB := common.HexToAddress("0x0000000000000000000000000000000000000005")
+
+	inRange, _ := entities.NewPosition(pool_0_1_medium, big.NewInt(1e18), -120, 120)
+	inRange.TokensOwed0, inRange.TokensOwed1 = big.NewInt(10), big.NewInt(20)
+	above, _ := entities.NewPosition(pool_0_1_medium, big.NewInt(1e18), 60, 600)
+	other, _ := entities.NewPosition(pool_1_2_low, big.NewInt(1e18), -100, 100)
+	other.TokensOwed0, other.TokensOwed1 = big.NewInt(5), big.NewInt(7)
+	portfolio := NewPortfolio(
+		&PortfolioPosition{TokenID: big.NewInt(1), Manager: managerA, Position: inRange},
+		&PortfolioPosition{TokenID: big.NewInt(2), Manager: managerA, Position: above},
+		&PortfolioPosition{TokenID: big.NewInt(3), Manager: managerB, Position: other},
+	)
+
+	// sums the amounts of each token
+	exposure, err := portfolio.Exposure()
+	assert.NoError(t, err)
+	assert.Len(t, exposure, 3)
+	expected0 := new(big.Int)
+	for _, position := range []*entities.Position{inRange, above} {
+		amount0, _ := position.Amount0()
+		expected0.Add(expected0, amount0.Quotient())
+	}
+	assert.True(t, exposure[0].Currency.Equal(token0))
+	assert.Equal(t, expected0, exposure[0].Quotient())
+	assert.True(t, exposure[1].Currency.Equal(token1))
+	assert.True(t, exposure[2].Currency.Equal(token2))
+
+	// sums the fees owed
+	fees := portfolio.UncollectedFees()
+	assert.Len(t, fees, 3)
+	assert.Equal(t, big.NewInt(10), fees[0].Quotient())
+	assert.Equal(t, big.NewInt(25), fees[1].Quotient())
+	assert.Equal(t, big.NewInt(7), fees[2].Quotient())
+
+	// adds the fees earned since the last update when the fee growth inside the range is given
+	inRange.FeeGrowthInside0LastX128, inRange.FeeGrowthInside1LastX128 = big.NewInt(0), constants.Q128
+	earned := NewPortfolio(&PortfolioPosition{
+		TokenID:              big.NewInt(1),
+		Manager:              managerA,
+		Position:             inRange,
+		FeeGrowthInside0X128: new(big.Int).Rsh(constants.Q128, 1),
+		FeeGrowthInside1X128: constants.Q128,
+	}).UncollectedFees()
+	assert.Len(t, earned, 2)
+	assert.Equal(t, big.NewInt(5e17+10), earned[0].Quotient())
+	assert.Equal(t, big.NewInt(20), earned[1].Quotient())
+
+	// splits the positions by whether they are in range
+	assert.Equal(t, []*PortfolioPosition{portfolio.Positions[0], portfolio.Positions[2]}, portfolio.InRange())
+	assert.Equal(t, []*PortfolioPosition{portfolio.Positions[1]}, portfolio.OutOfRange())
+
+	// values every token through the pools, including the fees
+	value, err := portfolio.Value(token1)
+	assert.NoError(t, err)
+	expected := core.FromRawAmount(token1, big.NewInt(0))
+	for _, amount := range append(exposure, fees...) {
+		expected = expected.Add(core.FromRawAmount(token1, amount.Quotient()))
+	}
+	assert.True(t, value.Currency.Equal(token1))
+	assert.True(t, value.EqualTo(expected.Fraction), "all pools are priced at 1:1")
+	value, err = portfolio.Value(token2)
+	assert.NoError(t, err)
+	assert.True(t, value.EqualTo(expected.Fraction), "prices token0 through two pools")
+	_, err = portfolio.Value(token3)
+	assert.ErrorIs(t, err, ErrNoPrice)
+	pool_2_3, _ := entities.NewPool(token2, token3, pool_1_2_low.Fee, pool_1_2_low.SqrtRatioX96, big.NewInt(0), 0, nil)
+	_, err = portfolio.Value(token3, pool_2_3)
+	assert.NoError(t, err)
+
+	// batches collects per position manager
+	params, err := portfolio.CollectAllCallParameters(recipientT)
+	assert.NoError(t, err)
+	assert.Len(t, params, 2)
+	collect1, _ := CollectCallParameters(&CollectOptions{
+		TokenID:               big.NewInt(1),
+		ExpectedCurrencyOwed0: core.FromRawAmount(token0, big.NewInt(10)),
+		ExpectedCurrencyOwed1: core.FromRawAmount(token1, big.NewInt(20)),
+		Recipient:             recipientT,
+	})
+	collect2, _ := CollectCallParameters(&CollectOptions{
+		TokenID:               big.NewInt(2),
+		ExpectedCurrencyOwed0: core.FromRawAmount(token0, big.NewInt(0)),
+		ExpectedCurrencyOwed1: core.FromRawAmount(token1, big.NewInt(0)),
+		Recipient:             recipientT,
+	})
+	batch, _ := EncodeMulticall([][]byte{collect1.Calldata, collect2.Calldata})
+	assert.Equal(t, batch, params[managerA].Calldata)
+	assert.Equal(t, 0, params[managerA].Value.Sign())
+	collect3, _ := CollectCallParameters(&CollectOptions{
+		TokenID:               big.NewInt(3),
+		ExpectedCurrencyOwed0: core.FromRawAmount(token1, big.NewInt(5)),
+		ExpectedCurrencyOwed1: core.FromRawAmount(token2, big.NewInt(7)),
+		Recipient:             recipientT,
+	})
+	assert.Equal(t, collect3.Calldata, params[managerB].Calldata)
+}
@@ -0,0 +1,135 @@
+package periphery
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrPositionPoolMismatch = errors.New("pool does not match position")
+
+// The values returned by `positions(tokenId)` of the position manager
+type PositionInfo struct {
+	Nonce                    *big.Int
+	Operator                 common.Address
+	Token0                   common.Address
+	Token1                   common.Address
+	Fee                      *big.Int
+	TickLower                *big.Int
+	TickUpper                *big.Int
+	Liquidity                *big.Int
+	FeeGrowthInside0LastX128 *big.Int
+	FeeGrowthInside1LastX128 *big.Int
+	TokensOwed0              *big.Int
+	TokensOwed1              *big.Int
+}
+
+/**
+ * Produces the calldata for reading a position from the position manager
+ * @param tokenID The ID of the token of the position
+ * @returns The calldata of `positions(tokenId)`
+ */
+func EncodePositions(tokenID *big.Int) ([]byte, error) {
+	abi := getNonFungiblePositionManagerABI()
+	return abi.Pack("positions", tokenID)
+}
+
+/**
+ * Decodes the return data of `positions(tokenId)`
+ * @param data The return data of the call
+ * @returns The position values
+ */
+func DecodePositions(data []byte) (*PositionInfo, error) {
+	abi := getNonFungiblePositionManagerABI()
+	var info PositionInfo
+	if err := abi.UnpackIntoInterface(&info, "positions", data); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+/**
+ * Constructs the position in a pool from the values returned by the position manager, including its fee tracking
+ * @param info The values returned by `positions(tokenId)`
+ * @param pool The pool of the position, e.g. as of the block the position was read at
+ * @returns The position
+ */
+func PositionFromInfo(info *PositionInfo, pool *entities.Pool) (*entities.Position, error) {
+	if info.Token0 != pool.Token0.Address || info.Token1 != pool.Token1.Address || info.Fee.Cmp(big.NewInt(int64(pool.Fee))) != 0 {
+		return nil, ErrPositionPoolMismatch
+	}
+	position, err := entities.NewPosition(pool, info.Liquidity, int(info.TickLower.Int64()), int(info.TickUpper.Int64()))
+	if err != nil {
+		return nil, err
+	}
+	position.FeeGrowthInside0LastX128 = info.FeeGrowthInside0LastX128
+	position.FeeGrowthInside1LastX128 = info.FeeGrowthInside1LastX128
+	position.TokensOwed0 = info.TokensOwed0
+	position.TokensOwed1 = info.TokensOwed1
+	return position, nil
+}
+
+/**
+ * Reads many positions from the position manager in a single multicall. The call fails as a whole if any of the
+ * token IDs does not exist.
+ * @param ctx The context of the call
+ * @param caller The backend to call the position manager through
+ * @param manager The address of the position manager
+ * @param tokenIDs The IDs of the tokens to read
+ * @param lookup Returns the pool of each position
+ * @returns The positions, in the order of the token IDs
+ */
+func FetchPositions(ctx context.Context, caller bind.ContractCaller, manager common.Address, tokenIDs []*big.Int, lookup PoolLookup) ([]*entities.Position, error) {
+	if len(tokenIDs) == 0 {
+		return nil, nil
+	}
+	calldatas := make([][]byte, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		calldata, err := EncodePositions(tokenID)
+		if err != nil {
+			return nil, err
+		}
+		calldatas[i] = calldata
+	}
+	data, err := EncodeMulticall(calldatas)
+	if err != nil {
+		return nil, err
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &manager, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// a single call is sent without the multicall wrapper
+	results := [][]byte{output}
+	if len(tokenIDs) > 1 {
+		if results, err = DecodeMulticall(output); err != nil {
+			return nil, err
+		}
+	}
+
+	positions := make([]*entities.Position, len(results))
+	for i, result := range results {
+		info, err := DecodePositions(result)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := lookup(info.Token0, info.Token1, constants.FeeAmount(info.Fee.Uint64()))
+		if err != nil {
+			return nil, err
+		}
+		if pool == nil {
+			return nil, ErrPoolNotFound
+		}
+		if positions[i], err = PositionFromInfo(info, pool); err != nil {
+			return nil, err
+		}
+	}
+	return positions, nil
+}
@@ -0,0 +1,162 @@
+package periphery
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// positionsCaller answers `positions` calls, bare or through multicall, from a fixed set of positions
+type positionsCaller struct {
+	positions map[int64]*PositionInfo
+	calls     int
+}
+
+func (c *positionsCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *positionsCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls++
+	multicall := GetABI(multicallABI).Methods["multicall"]
+	if string(call.Data[:4]) != string(multicall.ID) {
+		return c.answer(call.Data)
+	}
+	args, err := multicall.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	var results [][]byte
+	for _, calldata := range args[0].([][]byte) {
+		result, err := c.answer(calldata)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return multicall.Outputs.Pack(results)
+}
+
+func (c *positionsCaller) answer(calldata []byte) ([]byte, error) {
+	method := getNonFungiblePositionManagerABI().Methods["positions"]
+	args, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return nil, err
+	}
+	info, ok := c.positions[args[0].(*big.Int).Int64()]
+	if !ok {
+		return nil, errors.New("execution reverted: Invalid token ID")
+	}
+	return method.Outputs.Pack(info.Nonce, info.Operator, info.Token0, info.Token1, info.Fee, info.TickLower, info.TickUpper,
+		info.Liquidity, info.FeeGrowthInside0LastX128, info.FeeGrowthInside1LastX128, info.TokensOwed0, info.TokensOwed1)
+}
+
+func positionInfo(token0, token1 common.Address, fee constants.FeeAmount, tickLower, tickUpper, liquidity int64) *PositionInfo {
+	return &PositionInfo{
+		Nonce:                    big.NewInt(0),
+		Operator:                 common.Address{},
+		Token0:                   token0,
+		Token1:                   token1,
+		Fee:                      big.NewInt(int64(fee)),
+		TickLower:                big.NewInt(tickLower),
+		TickUpper:                big.NewInt(tickUpper),
+		Liquidity:                big.NewInt(liquidity),
+		FeeGrowthInside0LastX128: new(big.Int).Lsh(big.NewInt(3), 128),
+		FeeGrowthInside1LastX128: new(big.Int).Lsh(big.NewInt(5), 128),
+		TokensOwed0:              big.NewInt(7),
+		TokensOwed1:              big.NewInt(11),
+	}
+}
+
+func TestDecodePositions(t *testing.T) {
+	info := positionInfo(token0.Address, token1.Address, constants.FeeMedium, -120, 60, 1000)
+	data, err := (&positionsCaller{positions: map[int64]*PositionInfo{1: info}}).answer(mustEncodePositions(t, big.NewInt(1)))
+	assert.NoError(t, err)
+	decoded, err := DecodePositions(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, decoded.Nonce.Sign())
+	assert.Equal(t, info.Token0, decoded.Token0)
+	assert.Equal(t, info.Token1, decoded.Token1)
+	assert.Equal(t, info.Fee, decoded.Fee)
+	assert.Equal(t, info.TickLower, decoded.TickLower)
+	assert.Equal(t, info.TickUpper, decoded.TickUpper)
+	assert.Equal(t, info.Liquidity, decoded.Liquidity)
+	assert.Equal(t, info.FeeGrowthInside0LastX128, decoded.FeeGrowthInside0LastX128)
+	assert.Equal(t, info.FeeGrowthInside1LastX128, decoded.FeeGrowthInside1LastX128)
+	assert.Equal(t, info.TokensOwed0, decoded.TokensOwed0)
+	assert.Equal(t, info.TokensOwed1, decoded.TokensOwed1)
+
+	_, err = DecodePositions(data[:32])
+	assert.Error(t, err)
+}
+
+func TestPositionFromInfo(t *testing.T) {
+	info := positionInfo(token0.Address, token1.Address, constants.FeeMedium, -120, 60, 1000)
+	position, err := PositionFromInfo(info, pool_0_1_medium)
+	assert.NoError(t, err)
+	assert.Equal(t, pool_0_1_medium, position.Pool)
+	assert.Equal(t, -120, position.TickLower)
+	assert.Equal(t, 60, position.TickUpper)
+	assert.Equal(t, big.NewInt(1000), position.Liquidity)
+	assert.Equal(t, info.FeeGrowthInside0LastX128, position.FeeGrowthInside0LastX128)
+	assert.Equal(t, info.FeeGrowthInside1LastX128, position.FeeGrowthInside1LastX128)
+	assert.Equal(t, big.NewInt(7), position.TokensOwed0)
+	assert.Equal(t, big.NewInt(11), position.TokensOwed1)
+
+	// throws for a different pool
+	_, err = PositionFromInfo(info, pool_1_2_low)
+	assert.ErrorIs(t, err, ErrPositionPoolMismatch)
+	info.Fee = big.NewInt(int64(constants.FeeLow))
+	_, err = PositionFromInfo(info, pool_0_1_medium)
+	assert.ErrorIs(t, err, ErrPositionPoolMismatch)
+}
+
+func TestFetchPositions(t *testing.T) {
+	caller := &positionsCaller{positions: map[int64]*PositionInfo{
+		1: positionInfo(token0.Address, token1.Address, constants.FeeMedium, -120, 60, 1000),
+		2: positionInfo(token1.Address, token2.Address, constants.FeeLow, -10, 10, 2000),
+	}}
+	lookup := testPoolLookup(pool_0_1_medium, pool_1_2_low)
+	manager := common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88")
+
+	// reads many positions in one call
+	positions, err := FetchPositions(context.Background(), caller, manager, []*big.Int{big.NewInt(2), big.NewInt(1)}, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, caller.calls)
+	assert.Len(t, positions, 2)
+	assert.Equal(t, pool_1_2_low, positions[0].Pool)
+	assert.Equal(t, big.NewInt(2000), positions[0].Liquidity)
+	assert.Equal(t, pool_0_1_medium, positions[1].Pool)
+	assert.Equal(t, -120, positions[1].TickLower)
+	assert.Equal(t, big.NewInt(7), positions[1].TokensOwed0)
+
+	// reads a single position without multicall
+	positions, err = FetchPositions(context.Background(), caller, manager, []*big.Int{big.NewInt(1)}, lookup)
+	assert.NoError(t, err)
+	assert.Len(t, positions, 1)
+	assert.Equal(t, 60, positions[0].TickUpper)
+
+	// throws when the pool is unknown
+	_, err = FetchPositions(context.Background(), caller, manager, []*big.Int{big.NewInt(2)}, testPoolLookup(pool_0_1_medium))
+	assert.ErrorIs(t, err, ErrPoolNotFound)
+
+	// throws when the call reverts
+	_, err = FetchPositions(context.Background(), caller, manager, []*big.Int{big.NewInt(1), big.NewInt(3)}, lookup)
+	assert.Error(t, err)
+
+	positions, err = FetchPositions(context.Background(), caller, manager, nil, lookup)
+	assert.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+func mustEncodePositions(t *testing.T, tokenID *big.Int) []byte {
+	calldata, err := EncodePositions(tokenID)
+	assert.NoError(t, err)
+	return calldata
+}
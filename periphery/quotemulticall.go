@@ -0,0 +1,207 @@
+package periphery
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed contracts/lens/UniswapInterfaceMulticall.sol/UniswapInterfaceMulticall.json
+var interfaceMulticallABI []byte
+
+var (
+	ErrQuoteReverted        = errors.New("quote reverted")
+	ErrResultsMismatch      = errors.New("results do not match the quote requests")
+	ErrUnknownQuoterVersion = errors.New("unknown quoter version")
+)
+
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+)
+
+// The version of the quoter contract to batch quotes for
+type QuoterVersion int
+
+const (
+	QuoterV1 QuoterVersion = iota // The original Quoter, which returns only the quoted amount
+	QuoterV2                      // QuoterV2, which also returns the pools' prices after the swap and a gas estimate
+)
+
+// A call made by UniswapInterfaceMulticall
+type MulticallCall struct {
+	Target   common.Address
+	GasLimit *big.Int
+	CallData []byte
+}
+
+// The result of a call made by UniswapInterfaceMulticall
+type MulticallResult struct {
+	Success    bool
+	GasUsed    *big.Int
+	ReturnData []byte
+}
+
+// A quote to batch into one multicall
+type QuoteRequest struct {
+	Route     *entities.Route
+	Amount    *core.CurrencyAmount // Either an amount in, or an amount out
+	TradeType core.TradeType
+	Options   *QuoteOptions
+}
+
+// The result of one of a batch of quotes
+type QuoteResult struct {
+	Amount  *core.CurrencyAmount // The amount out for an exact input quote, or the amount in for an exact output quote
+	Quote   *QuoteV2Result       // The full decoded result of a QuoterV2 quote, nil for QuoterV1
+	GasUsed *big.Int             // The gas the quote call used
+	Err     error                // Why the quote failed, nil if it succeeded
+}
+
+/**
+ * Produces the calldata for calling UniswapInterfaceMulticall, which does not revert if a call fails
+ * @param calls The calls to make
+ */
+func EncodeInterfaceMulticall(calls []MulticallCall) ([]byte, error) {
+	return GetABI(interfaceMulticallABI).Pack("multicall", calls)
+}
+
+/**
+ * Decodes the result of a UniswapInterfaceMulticall call
+ * @param data The returned data
+ * @returns The block number the calls were made in and the result of each call
+ */
+func DecodeInterfaceMulticall(data []byte) (*big.Int, []MulticallResult, error) {
+	results, err := GetABI(interfaceMulticallABI).Unpack("multicall", data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var returnData []MulticallResult
+	abi.ConvertType(results[1], &returnData)
+	return results[0].(*big.Int), returnData, nil
+}
+
+/**
+ * Returns the reason a call reverted with
+ * @param data The data the call reverted with
+ * @returns The Error(string) message, a description of a Panic(uint256) code, or an empty string if there is none
+ */
+func DecodeRevertReason(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return ""
+		}
+		return reason
+	case bytes.Equal(data[:4], panicSelector) && len(data) == 36:
+		return fmt.Sprintf("panic: 0x%x", new(big.Int).SetBytes(data[4:]))
+	}
+	return ""
+}
+
+/**
+ * Produces the calldata for making a batch of quotes in one UniswapInterfaceMulticall call
+ * @param quoter The address of the quoter
+ * @param version The version of the quoter
+ * @param requests The quotes to make
+ * @param gasLimit The gas limit of each quote call
+ * @returns The call parameters for UniswapInterfaceMulticall
+ */
+func QuoteMulticallParameters(quoter common.Address, version QuoterVersion, requests []*QuoteRequest, gasLimit *big.Int) (*utils.MethodParameters, error) {
+	calls := make([]MulticallCall, len(requests))
+	for i, request := range requests {
+		var (
+			params *utils.MethodParameters
+			err    error
+		)
+		switch version {
+		case QuoterV1:
+			params, err = QuoteCallParameters(request.Route, request.Amount, request.TradeType, request.Options)
+		case QuoterV2:
+			params, err = QuoteV2CallParameters(request.Route, request.Amount, request.TradeType, request.Options)
+		default:
+			return nil, ErrUnknownQuoterVersion
+		}
+		if err != nil {
+			return nil, err
+		}
+		calls[i] = MulticallCall{Target: quoter, GasLimit: gasLimit, CallData: params.Calldata}
+	}
+	calldata, err := EncodeInterfaceMulticall(calls)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.MethodParameters{
+		Calldata: calldata,
+		Value:    big.NewInt(0),
+	}, nil
+}
+
+/**
+ * Decodes the result of a batch of quotes produced by QuoteMulticallParameters
+ * @param version The version of the quoter
+ * @param requests The quotes that were made
+ * @param data The data returned by UniswapInterfaceMulticall
+ * @returns The result of each quote, in the order of the requests, with the error of each quote that failed
+ */
+func DecodeQuoteMulticallResults(version QuoterVersion, requests []*QuoteRequest, data []byte) ([]QuoteResult, error) {
+	_, results, err := DecodeInterfaceMulticall(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(requests) {
+		return nil, ErrResultsMismatch
+	}
+
+	quotes := make([]QuoteResult, len(requests))
+	for i, request := range requests {
+		quotes[i].GasUsed = results[i].GasUsed
+		if !results[i].Success {
+			if reason := DecodeRevertReason(results[i].ReturnData); reason != "" {
+				quotes[i].Err = fmt.Errorf("%w: %s", ErrQuoteReverted, reason)
+			} else {
+				quotes[i].Err = ErrQuoteReverted
+			}
+			continue
+		}
+		switch version {
+		case QuoterV1:
+			quotes[i].Amount, quotes[i].Err = decodeQuoteV1Result(request, results[i].ReturnData)
+		case QuoterV2:
+			quotes[i].Quote, quotes[i].Err = DecodeQuoteV2Result(request.Route, request.TradeType, results[i].ReturnData)
+			if quotes[i].Err == nil {
+				quotes[i].Amount = quotes[i].Quote.Amount
+			}
+		default:
+			return nil, ErrUnknownQuoterVersion
+		}
+	}
+	return quotes, nil
+}
+
+// decodeQuoteV1Result decodes the amount returned by a QuoterV1 call produced by QuoteCallParameters
+func decodeQuoteV1Result(request *QuoteRequest, data []byte) (*core.CurrencyAmount, error) {
+	amount, err := abi.Arguments{{Type: uint256Type}}.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	currency := request.Route.Output
+	if request.TradeType == core.ExactOutput {
+		currency = request.Route.Input
+	}
+	return core.FromRawAmount(currency, amount[0].(*big.Int)), nil
+}
@@ -0,0 +1,144 @@
+package periphery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// returns 98 for any call
+	stubQuoterAddress = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	stubQuoterCode    = hexutil.MustDecode("0x60626000526020" + "6000f3")
+	// reverts with Error("SPL") for any call
+	stubRevertAddress = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	stubRevertCode    = hexutil.MustDecode("0x6064600c600039" + "60646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"53504c0000000000000000000000000000000000000000000000000000000000")
+)
+
+// deploy deploys a contract from its artifact on the simulated backend
+func deploy(t *testing.T, backend *backends.SimulatedBackend, auth *bind.TransactOpts, artifact []byte, args ...interface{}) common.Address {
+	var contract struct {
+		Bytecode string `json:"bytecode"`
+	}
+	if err := json.Unmarshal(artifact, &contract); err != nil {
+		t.Fatal(err)
+	}
+	address, _, _, err := bind.DeployContract(auth, GetABI(artifact), hexutil.MustDecode(contract.Bytecode), backend, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	return address
+}
+
+func TestQuoteMulticall(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	auth, _ := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	backend := backends.NewSimulatedBackend(ethcore.GenesisAlloc{
+		auth.From:         {Balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)},
+		stubQuoterAddress: {Code: stubQuoterCode, Balance: big.NewInt(0)},
+		stubRevertAddress: {Code: stubRevertCode, Balance: big.NewInt(0)},
+	}, 30_000_000)
+	defer backend.Close()
+	multicall := deploy(t, backend, auth, interfaceMulticallABI)
+	quoter := deploy(t, backend, auth, quoterABI, common.HexToAddress("0x1F98431c8aD98523631AE4a59f267346ea31F984"), weth.Address)
+
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1_medium}, token0, token1)
+	requests := []*QuoteRequest{
+		{Route: r, Amount: core.FromRawAmount(token0, big.NewInt(100)), TradeType: core.ExactInput},
+		{Route: route_0_1_2, Amount: core.FromRawAmount(token2, big.NewInt(100)), TradeType: core.ExactOutput},
+	}
+	call := func(quoter common.Address) []QuoteResult {
+		params, err := QuoteMulticallParameters(quoter, QuoterV1, requests, big.NewInt(1_000_000))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := backend.CallContract(context.Background(), ethereum.CallMsg{To: &multicall, Data: params.Calldata}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := DecodeQuoteMulticallResults(QuoterV1, requests, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, len(results))
+		return results
+	}
+
+	// successful quotes are decoded in the currency of the quoted amount
+	results := call(stubQuoterAddress)
+	for i, result := range results {
+		assert.Nil(t, result.Err)
+		assert.Nil(t, result.Quote)
+		assert.Equal(t, big.NewInt(98), result.Amount.Quotient())
+		assert.True(t, result.GasUsed.Sign() > 0)
+		if i == 0 {
+			assert.True(t, result.Amount.Currency.Equal(token1))
+		} else {
+			assert.True(t, result.Amount.Currency.Equal(token0))
+		}
+	}
+
+	// revert reasons are decoded
+	results = call(stubRevertAddress)
+	for _, result := range results {
+		assert.Nil(t, result.Amount)
+		assert.True(t, errors.Is(result.Err, ErrQuoteReverted))
+		assert.Equal(t, "quote reverted: SPL", result.Err.Error())
+	}
+
+	// the quoter reverts without a reason when the pools do not exist
+	results = call(quoter)
+	for _, result := range results {
+		assert.Nil(t, result.Amount)
+		assert.Equal(t, ErrQuoteReverted, result.Err)
+	}
+}
+
+func TestDecodeQuoteMulticallResults(t *testing.T) {
+	contract := GetABI(interfaceMulticallABI)
+	pool_0_1 := makePool(token0, token1)
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	requests := []*QuoteRequest{
+		{Route: r, Amount: core.FromRawAmount(token0, big.NewInt(100)), TradeType: core.ExactInput},
+		{Route: r, Amount: core.FromRawAmount(token0, big.NewInt(100)), TradeType: core.ExactInput},
+	}
+	quoteV2, _ := GetABI(quoterV2ABI).Methods["quoteExactInputSingle"].Outputs.Pack(big.NewInt(98), big.NewInt(1), uint32(0), big.NewInt(80000))
+	panicData := hexutil.MustDecode("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	data, _ := contract.Methods["multicall"].Outputs.Pack(big.NewInt(1), []MulticallResult{
+		{Success: true, GasUsed: big.NewInt(90000), ReturnData: quoteV2},
+		{Success: false, GasUsed: big.NewInt(100), ReturnData: panicData},
+	})
+
+	results, err := DecodeQuoteMulticallResults(QuoterV2, requests, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, big.NewInt(98), results[0].Amount.Quotient())
+	assert.Equal(t, big.NewInt(1), results[0].Quote.Pools[0].SqrtPriceX96After)
+	assert.Equal(t, big.NewInt(90000), results[0].GasUsed)
+	assert.Equal(t, "quote reverted: panic: 0x11", results[1].Err.Error())
+
+	_, err = DecodeQuoteMulticallResults(QuoterV2, requests[:1], data)
+	assert.Equal(t, ErrResultsMismatch, err)
+
+	assert.Equal(t, "", DecodeRevertReason(nil))
+}
@@ -0,0 +1,217 @@
+package periphery
+
+import (
+	_ "embed"
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed contracts/interfaces/IQuoterV2.sol/IQuoterV2.json
+var quoterV2ABI []byte
+
+var ErrQuoteLengthMismatch = errors.New("quote does not match the route's pools")
+
+type QuoteExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	AmountIn          *big.Int
+	Fee               *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+type QuoteExactOutputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Amount            *big.Int
+	Fee               *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// The state of a pool after the quoted swap passed through it
+type PoolQuote struct {
+	Pool                    *entities.Pool // The pool of the route, in its state before the swap
+	SqrtPriceX96After       *big.Int       // The sqrt price of the pool after the swap
+	InitializedTicksCrossed uint32         // The number of initialized ticks the swap crossed in the pool
+}
+
+// The decoded result of a QuoterV2 call
+type QuoteV2Result struct {
+	Amount      *core.CurrencyAmount // The amount out for an exact input quote, or the amount in for an exact output quote
+	Pools       []PoolQuote          // The state of each pool after the swap, in the order of the route's pools
+	GasEstimate *big.Int             // The gas the swap is estimated to cost
+}
+
+/**
+ * Produces the on-chain method name of the appropriate function within QuoterV2,
+ * and the relevant hex encoded parameters.
+ * @param route The swap route, a list of pools through which a swap can occur
+ * @param amount The amount of the quote, either an amount in, or an amount out
+ * @param tradeType The trade type, either exact input or exact output
+ * @returns The formatted calldata
+ */
+func QuoteV2CallParameters(
+	route *entities.Route,
+	amount *core.CurrencyAmount,
+	tradeType core.TradeType,
+	options *QuoteOptions,
+) (*utils.MethodParameters, error) {
+	quoteAmount := amount.Quotient()
+	abi := GetABI(quoterV2ABI)
+	var (
+		calldata []byte
+		err      error
+	)
+	sqrtPriceLimitX96 := big.NewInt(0)
+	if options != nil && options.SqrtPriceLimitX96 != nil {
+		sqrtPriceLimitX96 = options.SqrtPriceLimitX96
+	}
+
+	if len(route.Pools) == 1 {
+		tokenIn, tokenOut, fee := route.TokenPath[0].Address, route.TokenPath[1].Address, big.NewInt(int64(route.Pools[0].Fee))
+		if tradeType == core.ExactInput {
+			calldata, err = abi.Pack("quoteExactInputSingle", &QuoteExactInputSingleParams{
+				TokenIn:           tokenIn,
+				TokenOut:          tokenOut,
+				AmountIn:          quoteAmount,
+				Fee:               fee,
+				SqrtPriceLimitX96: sqrtPriceLimitX96,
+			})
+		} else {
+			calldata, err = abi.Pack("quoteExactOutputSingle", &QuoteExactOutputSingleParams{
+				TokenIn:           tokenIn,
+				TokenOut:          tokenOut,
+				Amount:            quoteAmount,
+				Fee:               fee,
+				SqrtPriceLimitX96: sqrtPriceLimitX96,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if sqrtPriceLimitX96.Sign() != 0 {
+			return nil, ErrMultihopPriceLimit
+		}
+		path, err := EncodeRouteToPath(route, tradeType == core.ExactOutput)
+		if err != nil {
+			return nil, err
+		}
+		if tradeType == core.ExactInput {
+			calldata, err = abi.Pack("quoteExactInput", path, quoteAmount)
+		} else {
+			calldata, err = abi.Pack("quoteExactOutput", path, quoteAmount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &utils.MethodParameters{
+		Calldata: calldata,
+		Value:    big.NewInt(0),
+	}, nil
+}
+
+/**
+ * Decodes the data returned by a QuoterV2 call produced by QuoteV2CallParameters
+ * @param route The route that was quoted
+ * @param tradeType The trade type that was quoted
+ * @param data The returned data
+ * @returns The quoted amount and the state of each of the route's pools after the swap
+ */
+func DecodeQuoteV2Result(route *entities.Route, tradeType core.TradeType, data []byte) (*QuoteV2Result, error) {
+	abi := GetABI(quoterV2ABI)
+	singleHop := len(route.Pools) == 1
+	var method string
+	switch {
+	case singleHop && tradeType == core.ExactInput:
+		method = "quoteExactInputSingle"
+	case singleHop:
+		method = "quoteExactOutputSingle"
+	case tradeType == core.ExactInput:
+		method = "quoteExactInput"
+	default:
+		method = "quoteExactOutput"
+	}
+	results, err := abi.Unpack(method, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sqrtPrices   []*big.Int
+		ticksCrossed []uint32
+	)
+	if singleHop {
+		sqrtPrices = []*big.Int{results[1].(*big.Int)}
+		ticksCrossed = []uint32{results[2].(uint32)}
+	} else {
+		sqrtPrices = results[1].([]*big.Int)
+		ticksCrossed = results[2].([]uint32)
+	}
+	if len(sqrtPrices) != len(route.Pools) || len(ticksCrossed) != len(route.Pools) {
+		return nil, ErrQuoteLengthMismatch
+	}
+
+	// the lists follow the encoded path, which is reversed for exact output
+	pools := make([]PoolQuote, len(route.Pools))
+	for i, pool := range route.Pools {
+		j := i
+		if tradeType == core.ExactOutput {
+			j = len(route.Pools) - 1 - i
+		}
+		pools[i] = PoolQuote{
+			Pool:                    pool,
+			SqrtPriceX96After:       sqrtPrices[j],
+			InitializedTicksCrossed: ticksCrossed[j],
+		}
+	}
+
+	currency := route.Output
+	if tradeType == core.ExactOutput {
+		currency = route.Input
+	}
+	return &QuoteV2Result{
+		Amount:      core.FromRawAmount(currency, results[0].(*big.Int)),
+		Pools:       pools,
+		GasEstimate: results[3].(*big.Int),
+	}, nil
+}
+
+/**
+ * Simulates a quote locally with the route's pools, to check against the result of a QuoterV2 call.
+ * The initialized ticks crossed and the gas estimate are not simulated and are left zero and nil.
+ * @param route The swap route
+ * @param amount The amount of the quote, either an amount in, or an amount out
+ * @param tradeType The trade type, either exact input or exact output
+ * @returns The simulated amount and the sqrt price of each pool after the swap
+ */
+func SimulateQuoteV2(route *entities.Route, amount *core.CurrencyAmount, tradeType core.TradeType) (*QuoteV2Result, error) {
+	pools := make([]PoolQuote, len(route.Pools))
+	current := amount.Wrapped()
+	if tradeType == core.ExactInput {
+		for i, pool := range route.Pools {
+			next, after, err := pool.GetOutputAmount(current, nil)
+			if err != nil {
+				return nil, err
+			}
+			pools[i] = PoolQuote{Pool: pool, SqrtPriceX96After: after.SqrtRatioX96}
+			current = next
+		}
+		return &QuoteV2Result{Amount: core.FromRawAmount(route.Output, current.Quotient()), Pools: pools}, nil
+	}
+	for i := len(route.Pools) - 1; i >= 0; i-- {
+		pool := route.Pools[i]
+		next, after, err := pool.GetInputAmount(current, nil)
+		if err != nil {
+			return nil, err
+		}
+		pools[i] = PoolQuote{Pool: pool, SqrtPriceX96After: after.SqrtRatioX96}
+		current = next
+	}
+	return &QuoteV2Result{Amount: core.FromRawAmount(route.Input, current.Quotient()), Pools: pools}, nil
+}
@@ -0,0 +1,113 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteV2CallParameters(t *testing.T) {
+	pool_0_1 := makePool(token0, token1)
+	pool_1_weth := makePool(token1, weth)
+	contract := GetABI(quoterV2ABI)
+
+	// single-hop exact input
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	params, err := QuoteV2CallParameters(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xc6a5026a0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000640000000000000000000000000000000000000000000000000000000000000bb80000000000000000000000000000000000000000000000000000000000000000", hexutil.Encode(params.Calldata))
+	assert.Equal(t, "0x00", utils.ToHex(params.Value))
+
+	// single-hop exact output with a price limit
+	limit := new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil)
+	params, err = QuoteV2CallParameters(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactOutput, &QuoteOptions{SqrtPriceLimitX96: limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, _ := contract.MethodById(params.Calldata[:4])
+	assert.Equal(t, "quoteExactOutputSingle", method.Name)
+	args, _ := method.Inputs.Unpack(params.Calldata[4:])
+	single := abi.ConvertType(args[0], new(QuoteExactOutputSingleParams)).(*QuoteExactOutputSingleParams)
+	assert.Equal(t, token0.Address, single.TokenIn)
+	assert.Equal(t, token1.Address, single.TokenOut)
+	assert.Equal(t, big.NewInt(100), single.Amount)
+	assert.Equal(t, big.NewInt(3000), single.Fee)
+	assert.Equal(t, limit, single.SqrtPriceLimitX96)
+
+	// multi-hop exact output encodes the reversed path
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1, pool_1_weth}, token0, weth)
+	params, err = QuoteV2CallParameters(r, core.FromRawAmount(weth, big.NewInt(100)), core.ExactOutput, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, _ = contract.MethodById(params.Calldata[:4])
+	assert.Equal(t, "quoteExactOutput", method.Name)
+	args, _ = method.Inputs.Unpack(params.Calldata[4:])
+	assert.Equal(t, "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2000bb80000000000000000000000000000000000000002000bb80000000000000000000000000000000000000001", hexutil.Encode(args[0].([]byte)))
+	assert.Equal(t, big.NewInt(100), args[1])
+
+	_, err = QuoteV2CallParameters(r, core.FromRawAmount(weth, big.NewInt(100)), core.ExactOutput, &QuoteOptions{SqrtPriceLimitX96: limit})
+	assert.Equal(t, ErrMultihopPriceLimit, err)
+}
+
+func TestDecodeQuoteV2Result(t *testing.T) {
+	pool_0_1 := makePool(token0, token1)
+	pool_1_weth := makePool(token1, weth)
+	contract := GetABI(quoterV2ABI)
+
+	// single-hop exact input matches the local simulation
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	simulated, err := SimulateQuoteV2(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(98), simulated.Amount.Quotient())
+	data, _ := contract.Methods["quoteExactInputSingle"].Outputs.Pack(big.NewInt(98), simulated.Pools[0].SqrtPriceX96After, uint32(0), big.NewInt(80000))
+	quote, err := DecodeQuoteV2Result(r, core.ExactInput, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, quote.Amount.Currency.Equal(token1))
+	assert.Equal(t, simulated.Amount.Quotient(), quote.Amount.Quotient())
+	assert.Equal(t, pool_0_1, quote.Pools[0].Pool)
+	assert.Equal(t, simulated.Pools[0].SqrtPriceX96After, quote.Pools[0].SqrtPriceX96After)
+	assert.Equal(t, big.NewInt(80000), quote.GasEstimate)
+
+	// multi-hop exact output lists are mapped back onto the route's pools
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1, pool_1_weth}, token0, weth)
+	simulated, err = SimulateQuoteV2(r, core.FromRawAmount(weth, big.NewInt(100)), core.ExactOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(104), simulated.Amount.Quotient())
+	data, _ = contract.Methods["quoteExactOutput"].Outputs.Pack(
+		big.NewInt(104),
+		[]*big.Int{simulated.Pools[1].SqrtPriceX96After, simulated.Pools[0].SqrtPriceX96After},
+		[]uint32{2, 1},
+		big.NewInt(150000),
+	)
+	quote, err = DecodeQuoteV2Result(r, core.ExactOutput, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, quote.Amount.Currency.Equal(token0))
+	assert.Equal(t, big.NewInt(104), quote.Amount.Quotient())
+	for i := range r.Pools {
+		assert.Equal(t, r.Pools[i], quote.Pools[i].Pool)
+		assert.Equal(t, simulated.Pools[i].SqrtPriceX96After, quote.Pools[i].SqrtPriceX96After)
+	}
+	assert.Equal(t, uint32(1), quote.Pools[0].InitializedTicksCrossed)
+	assert.Equal(t, uint32(2), quote.Pools[1].InitializedTicksCrossed)
+
+	data, _ = contract.Methods["quoteExactOutput"].Outputs.Pack(big.NewInt(104), []*big.Int{big.NewInt(1)}, []uint32{0}, big.NewInt(0))
+	_, err = DecodeQuoteV2Result(r, core.ExactOutput, data)
+	assert.Equal(t, ErrQuoteLengthMismatch, err)
+}
@@ -0,0 +1,45 @@
+package periphery
+
+import (
+	"errors"
+
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+)
+
+var ErrRangeOrderNotFilled = errors.New("range order not filled")
+
+/**
+ * Produces the calldata for placing a range order by minting its position
+ * @param order The range order to place
+ * @param options Additional information necessary for generating the calldata
+ * @returns The call parameters
+ */
+func RangeOrderCallParameters(order *entities.RangeOrder, opts *MintOptions) (*utils.MethodParameters, error) {
+	return AddCallParameters(order.Position, &AddLiquidityOptions{
+		CommonAddLiquidityOptions: opts.CommonAddLiquidityOptions,
+		MintSpecificOptions:       opts.MintSpecificOptions,
+	})
+}
+
+/**
+ * Produces the calldata for withdrawing a range order once the price has crossed its whole range
+ * @param order The range order to withdraw
+ * @param pool The pool the order was placed in, at its current state
+ * @param options Additional information necessary for generating the calldata
+ * @returns The call parameters, or ErrRangeOrderNotFilled if part of the sell token is still in the order
+ */
+func RangeOrderWithdrawParameters(order *entities.RangeOrder, pool *entities.Pool, opts *RemoveLiquidityOptions) (*utils.MethodParameters, error) {
+	filled, err := order.IsFilled(pool)
+	if err != nil {
+		return nil, err
+	}
+	if !filled {
+		return nil, ErrRangeOrderNotFilled
+	}
+	position, err := entities.NewPosition(pool, order.Position.Liquidity, order.Position.TickLower, order.Position.TickUpper)
+	if err != nil {
+		return nil, err
+	}
+	return RemoveCallParameters(position, opts)
+}
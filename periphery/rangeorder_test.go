@@ -0,0 +1,60 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeOrderCallParameters(t *testing.T) {
+	order, err := entities.NewRangeOrder(pool01T, token0T, big.NewInt(1e18), core.NewPrice(token0T, token1T, big.NewInt(100), big.NewInt(105)))
+	assert.NoError(t, err)
+	opts := &MintOptions{
+		CommonAddLiquidityOptions: &CommonAddLiquidityOptions{SlippageTolerance: slippageToleranceT, Deadline: deadlineT},
+		MintSpecificOptions:       &MintSpecificOptions{Recipient: recipientT},
+	}
+	params, err := RangeOrderCallParameters(order, opts)
+	assert.NoError(t, err)
+	expected, err := AddCallParameters(order.Position, &AddLiquidityOptions{
+		CommonAddLiquidityOptions: opts.CommonAddLiquidityOptions,
+		MintSpecificOptions:       opts.MintSpecificOptions,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, params)
+}
+
+func TestRangeOrderWithdrawParameters(t *testing.T) {
+	order, err := entities.NewRangeOrder(pool01T, token0T, big.NewInt(1e18), core.NewPrice(token0T, token1T, big.NewInt(100), big.NewInt(105)))
+	assert.NoError(t, err)
+	opts := &RemoveLiquidityOptions{
+		TokenID:             tokenIDT,
+		LiquidityPercentage: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Deadline:            deadlineT,
+		BurnToken:           true,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: core.FromRawAmount(token0T, big.NewInt(0)),
+			ExpectedCurrencyOwed1: core.FromRawAmount(token1T, big.NewInt(0)),
+			Recipient:             recipientT,
+		},
+	}
+
+	// throws until the whole range is crossed
+	_, err = RangeOrderWithdrawParameters(order, pool01T, opts)
+	assert.ErrorIs(t, err, ErrRangeOrderNotFilled)
+
+	sqrtRatioX96, _ := utils.GetSqrtRatioAtTick(order.Position.TickUpper)
+	crossed, err := entities.NewPool(token0T, token1T, constants.FeeMedium, sqrtRatioX96, big.NewInt(0), order.Position.TickUpper, nil)
+	assert.NoError(t, err)
+	params, err := RangeOrderWithdrawParameters(order, crossed, opts)
+	assert.NoError(t, err)
+	position, _ := entities.NewPosition(crossed, order.Position.Liquidity, order.Position.TickLower, order.Position.TickUpper)
+	expected, err := RemoveCallParameters(position, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, params)
+}
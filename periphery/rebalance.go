@@ -0,0 +1,158 @@
+package periphery
+
+import (
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Options for moving a position's liquidity to a new tick range
+type RebalanceOptions struct {
+	TokenID           *big.Int             // The ID of the token to exit
+	TickLower         int                  // The lower tick of the new position
+	TickUpper         int                  // The upper tick of the new position
+	SlippageTolerance *core.Percent        // How much the pool price is allowed to move, applied to the exit, the swap and the mint
+	Deadline          *big.Int             // When the transactions expire, in epoch seconds
+	Recipient         common.Address       // The account that receives the freed tokens, the swap output and the new NFT
+	BurnToken         bool                 // Whether the old NFT should be burned after the exit, by default false
+	FeesOwed0         *core.CurrencyAmount // The optional amount of token0 fees owed to the old position, collected along with the exit
+	FeesOwed1         *core.CurrencyAmount // The optional amount of token1 fees owed to the old position, collected along with the exit
+}
+
+// The calldata and expected amounts of a rebalance
+type RebalancePlan struct {
+	Remove *utils.MethodParameters // The call to the position manager exiting the old position
+	Swap   *utils.MethodParameters // The call to the swap router fitting the freed tokens to the new range, nil if no swap is needed
+	Mint   *utils.MethodParameters // The call to the position manager minting the new position
+
+	Trade    *entities.Trade    // The swap between the exit and the mint, nil if no swap is needed
+	Position *entities.Position // The new position, in the pool as it is expected to be after the swap
+
+	Amount0Freed *big.Int // The token0 expected from the exit, including the fees owed
+	Amount1Freed *big.Int // The token1 expected from the exit, including the fees owed
+	Amount0Mint  *big.Int // The token0 deposited into the new position
+	Amount1Mint  *big.Int // The token1 deposited into the new position
+	Amount0Dust  *big.Int // The token0 expected to be left over after the mint
+	Amount1Dust  *big.Int // The token1 expected to be left over after the mint
+}
+
+/**
+ * Plans moving all the liquidity of a position to a new tick range: exiting the position, swapping the freed tokens
+ * to the ratio of the new range, and minting the new position. The swap is simulated against the pool of the position
+ * as given, so if the old position makes up much of the active liquidity, pass a position whose pool has it removed.
+ * The new position is sized for the minimum output of the swap, so the mint never needs more than the swap delivers.
+ * @param position The position to exit
+ * @param options Additional information necessary for generating the calldata
+ * @returns The calldata for the exit, the swap and the mint, and the expected amounts
+ */
+func RebalanceCallParameters(position *entities.Position, opts *RebalanceOptions) (*RebalancePlan, error) {
+	pool := position.Pool
+	feesOwed0, feesOwed1 := opts.FeesOwed0, opts.FeesOwed1
+	if feesOwed0 == nil {
+		feesOwed0 = core.FromRawAmount(pool.Token0, constants.Zero)
+	}
+	if feesOwed1 == nil {
+		feesOwed1 = core.FromRawAmount(pool.Token1, constants.Zero)
+	}
+
+	remove, err := RemoveCallParameters(position, &RemoveLiquidityOptions{
+		TokenID:             opts.TokenID,
+		LiquidityPercentage: core.NewPercent(constants.One, constants.One),
+		SlippageTolerance:   opts.SlippageTolerance,
+		Deadline:            opts.Deadline,
+		BurnToken:           opts.BurnToken,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: feesOwed0,
+			ExpectedCurrencyOwed1: feesOwed1,
+			Recipient:             opts.Recipient,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the tokens freed at the current price
+	amount0, err := position.Amount0()
+	if err != nil {
+		return nil, err
+	}
+	amount1, err := position.Amount1()
+	if err != nil {
+		return nil, err
+	}
+	amount0Freed := new(big.Int).Add(amount0.Quotient(), feesOwed0.Quotient())
+	amount1Freed := new(big.Int).Add(amount1.Quotient(), feesOwed1.Quotient())
+
+	trade, newPosition, err := entities.SwapToRatio(pool, opts.TickLower, opts.TickUpper, amount0Freed, amount1Freed)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &RebalancePlan{
+		Remove:       remove,
+		Trade:        trade,
+		Amount0Freed: amount0Freed,
+		Amount1Freed: amount1Freed,
+	}
+
+	// the balances expected after the swap, and the ones the mint is sized for
+	expected0, expected1 := new(big.Int).Set(amount0Freed), new(big.Int).Set(amount1Freed)
+	if trade != nil {
+		plan.Swap, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+			SlippageTolerance: opts.SlippageTolerance,
+			Recipient:         opts.Recipient,
+			Deadline:          opts.Deadline,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		minimumOut, err := trade.MinimumAmountOut(opts.SlippageTolerance, nil)
+		if err != nil {
+			return nil, err
+		}
+		amountIn, amountOut := trade.InputAmount().Quotient(), trade.OutputAmount().Quotient()
+		available0, available1 := new(big.Int).Set(amount0Freed), new(big.Int).Set(amount1Freed)
+		if trade.InputAmount().Currency.Equal(pool.Token0) {
+			expected0.Sub(expected0, amountIn)
+			expected1.Add(expected1, amountOut)
+			available0.Sub(available0, amountIn)
+			available1.Add(available1, minimumOut.Quotient())
+		} else {
+			expected1.Sub(expected1, amountIn)
+			expected0.Add(expected0, amountOut)
+			available1.Sub(available1, amountIn)
+			available0.Add(available0, minimumOut.Quotient())
+		}
+		newPosition, err = entities.FromAmounts(newPosition.Pool, opts.TickLower, opts.TickUpper, available0, available1, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plan.Position = newPosition
+
+	plan.Mint, err = AddCallParameters(newPosition, &AddLiquidityOptions{
+		CommonAddLiquidityOptions: &CommonAddLiquidityOptions{
+			SlippageTolerance: opts.SlippageTolerance,
+			Deadline:          opts.Deadline,
+		},
+		MintSpecificOptions: &MintSpecificOptions{
+			Recipient: opts.Recipient,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Amount0Mint, plan.Amount1Mint, err = newPosition.MintAmounts()
+	if err != nil {
+		return nil, err
+	}
+	plan.Amount0Dust = new(big.Int).Sub(expected0, plan.Amount0Mint)
+	plan.Amount1Dust = new(big.Int).Sub(expected1, plan.Amount1Mint)
+	return plan, nil
+}
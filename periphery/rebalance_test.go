@@ -0,0 +1,91 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebalanceCallParameters(t *testing.T) {
+	pool := makePool(token0, token1)
+
+	// moves an in range position above the price
+	position, err := entities.NewPosition(pool, big.NewInt(100000), -120, 120)
+	assert.NoError(t, err)
+	opts := &RebalanceOptions{
+		TokenID:           tokenIDT,
+		TickLower:         60,
+		TickUpper:         600,
+		SlippageTolerance: slippageToleranceT,
+		Deadline:          deadlineT,
+		Recipient:         recipientT,
+		FeesOwed0:         core.FromRawAmount(token0, big.NewInt(10)),
+	}
+	plan, err := RebalanceCallParameters(position, opts)
+	assert.NoError(t, err)
+
+	remove, err := RemoveCallParameters(position, &RemoveLiquidityOptions{
+		TokenID:             tokenIDT,
+		LiquidityPercentage: core.NewPercent(big.NewInt(1), big.NewInt(1)),
+		SlippageTolerance:   slippageToleranceT,
+		Deadline:            deadlineT,
+		CollectOptions: &CollectOptions{
+			ExpectedCurrencyOwed0: opts.FeesOwed0,
+			ExpectedCurrencyOwed1: core.FromRawAmount(token1, big.NewInt(0)),
+			Recipient:             recipientT,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, remove, plan.Remove)
+
+	amount0, _ := position.Amount0()
+	amount1, _ := position.Amount1()
+	assert.Equal(t, new(big.Int).Add(amount0.Quotient(), big.NewInt(10)), plan.Amount0Freed)
+	assert.Equal(t, amount1.Quotient(), plan.Amount1Freed)
+
+	assert.NotNil(t, plan.Trade)
+	assert.True(t, plan.Trade.InputAmount().Currency.Equal(token1))
+	assert.Equal(t, plan.Amount1Freed, plan.Trade.InputAmount().Quotient())
+	swap, err := SwapCallParameters([]*entities.Trade{plan.Trade}, &SwapOptions{
+		SlippageTolerance: slippageToleranceT,
+		Recipient:         recipientT,
+		Deadline:          deadlineT,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, swap, plan.Swap)
+
+	assert.Equal(t, 60, plan.Position.TickLower)
+	assert.Equal(t, 600, plan.Position.TickUpper)
+	mint, err := AddCallParameters(plan.Position, &AddLiquidityOptions{
+		CommonAddLiquidityOptions: &CommonAddLiquidityOptions{SlippageTolerance: slippageToleranceT, Deadline: deadlineT},
+		MintSpecificOptions:       &MintSpecificOptions{Recipient: recipientT},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, mint, plan.Mint)
+
+	// the mint is sized for the minimum swap output, the rest is dust
+	minimumOut, err := plan.Trade.MinimumAmountOut(slippageToleranceT, nil)
+	assert.NoError(t, err)
+	assert.True(t, plan.Amount0Mint.Cmp(new(big.Int).Add(plan.Amount0Freed, minimumOut.Quotient())) <= 0)
+	assert.Equal(t, 0, plan.Amount1Mint.Sign())
+	assert.Equal(t, new(big.Int).Add(plan.Amount0Freed, plan.Trade.OutputAmount().Quotient()), new(big.Int).Add(plan.Amount0Mint, plan.Amount0Dust))
+	assert.Equal(t, 0, plan.Amount1Dust.Sign())
+
+	// does not swap when the freed tokens already fit the new range
+	position, err = entities.NewPosition(pool, big.NewInt(100000), 120, 240)
+	assert.NoError(t, err)
+	plan, err = RebalanceCallParameters(position, opts)
+	assert.NoError(t, err)
+	assert.Nil(t, plan.Trade)
+	assert.Nil(t, plan.Swap)
+	assert.Equal(t, plan.Amount0Freed, new(big.Int).Add(plan.Amount0Mint, plan.Amount0Dust))
+	assert.True(t, plan.Amount0Dust.Cmp(big.NewInt(1)) <= 0)
+
+	// throws for an invalid new range
+	opts.TickLower, opts.TickUpper = 600, 60
+	_, err = RebalanceCallParameters(position, opts)
+	assert.ErrorIs(t, err, entities.ErrTickOrder)
+}
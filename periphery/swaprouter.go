@@ -24,12 +24,13 @@ var (
 
 // Options for producing the arguments to send calls to the router.
 type SwapOptions struct {
-	SlippageTolerance *core.Percent  // How much the execution price is allowed to move unfavorably from the trade execution price.
-	Recipient         common.Address // The account that should receive the output.
-	Deadline          *big.Int       // When the transaction expires, in epoch seconds.
-	InputTokenPermit  *PermitOptions // The optional permit parameters for spending the input.
-	SqrtPriceLimitX96 *big.Int       // The optional price limit for the trade.
-	Fee               *FeeOptions    // Optional information for taking a fee on output.
+	SlippageTolerance *core.Percent     // How much the execution price is allowed to move unfavorably from the trade execution price.
+	Recipient         common.Address    // The account that should receive the output.
+	Deadline          *big.Int          // When the transaction expires, in epoch seconds.
+	InputTokenPermit  *PermitOptions    // The optional permit parameters for spending the input.
+	SqrtPriceLimitX96 *big.Int          // The optional price limit for the trade.
+	Fee               *FeeOptions       // Optional information for taking a fee on output.
+	Router            SwapRouterVersion // The router to produce calldata for, the original SwapRouter by default.
 }
 
 type ExactInputSingleParams struct {
@@ -70,7 +71,7 @@ type ExactOutputParams struct {
 	AmountInMaximum *big.Int
 }
 
-// Represents the Uniswap V3 SwapRouter
+// Represents the Uniswap V3 SwapRouter and SwapRouter02
 
 /**
  * Produces the on-chain method name to call and the hex encoded parameters to pass as arguments for a given trade.
@@ -78,7 +79,6 @@ type ExactOutputParams struct {
  * @param options options for the call parameters
  */
 func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.MethodParameters, error) {
-	abi := GetABI(swapRouterABI)
 	sampleTrade := trades[0]
 	tokenIn := sampleTrade.InputAmount().Currency.Wrapped()
 	tokenOut := sampleTrade.OutputAmount().Currency.Wrapped()
@@ -141,6 +141,11 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 	recipient := options.Recipient
 	if routerMustCustody {
 		recipient = constants.AddressZero
+		if options.Router == SwapRouter02 {
+			recipient = AddressThis
+		}
+	} else if options.Router == SwapRouter02 && recipient == constants.AddressZero {
+		recipient = MsgSender
 	}
 
 	sqrtPriceLimitX96 := big.NewInt(0)
@@ -175,7 +180,7 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 						AmountOutMinimum:  amountOut.Quotient(),
 						SqrtPriceLimitX96: sqrtPriceLimitX96,
 					}
-					calldata, err := abi.Pack("exactInputSingle", exactInputSingleParams)
+					calldata, err := encodeExactInputSingle(options.Router, exactInputSingleParams)
 					if err != nil {
 						return nil, err
 					}
@@ -191,7 +196,7 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 						AmountInMaximum:   amountIn.Quotient(),
 						SqrtPriceLimitX96: sqrtPriceLimitX96,
 					}
-					calldata, err := abi.Pack("exactOutputSingle", exactOutputSingleParams)
+					calldata, err := encodeExactOutputSingle(options.Router, exactOutputSingleParams)
 					if err != nil {
 						return nil, err
 					}
@@ -215,7 +220,7 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 						AmountIn:         amountIn.Quotient(),
 						AmountOutMinimum: amountOut.Quotient(),
 					}
-					calldata, err := abi.Pack("exactInput", exactInputParams)
+					calldata, err := encodeExactInput(options.Router, exactInputParams)
 					if err != nil {
 						return nil, err
 					}
@@ -228,7 +233,7 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 						AmountOut:       amountOut.Quotient(),
 						AmountInMaximum: amountIn.Quotient(),
 					}
-					calldata, err := abi.Pack("exactOutput", exactOutputParams)
+					calldata, err := encodeExactOutput(options.Router, exactOutputParams)
 					if err != nil {
 						return nil, err
 					}
@@ -240,22 +245,26 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 
 	// unwrap
 	if routerMustCustody {
+		encodeUnwrapWETH9, encodeSweepToken := EncodeUnwrapWETH9, EncodeSweepToken
+		if options.Router == SwapRouter02 {
+			encodeUnwrapWETH9, encodeSweepToken = EncodeUnwrapWETH9Router02, EncodeSweepTokenRouter02
+		}
 		if options.Fee != nil {
 			if outputIsNative {
-				calldata, err := EncodeUnwrapWETH9(totalAmountOut.Quotient(), options.Recipient, options.Fee)
+				calldata, err := encodeUnwrapWETH9(totalAmountOut.Quotient(), options.Recipient, options.Fee)
 				if err != nil {
 					return nil, err
 				}
 				calldatas = append(calldatas, calldata)
 			} else {
-				calldata, err := EncodeSweepToken(tokenOut, totalAmountOut.Quotient(), options.Recipient, options.Fee)
+				calldata, err := encodeSweepToken(tokenOut, totalAmountOut.Quotient(), options.Recipient, options.Fee)
 				if err != nil {
 					return nil, err
 				}
 				calldatas = append(calldatas, calldata)
 			}
 		} else {
-			calldata, err := EncodeUnwrapWETH9(totalAmountOut.Quotient(), options.Recipient, nil)
+			calldata, err := encodeUnwrapWETH9(totalAmountOut.Quotient(), options.Recipient, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -267,7 +276,16 @@ func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.
 	if mustRefund {
 		calldatas = append(calldatas, EncodeRefundETH())
 	}
-	call, err := EncodeMulticall(calldatas)
+	var (
+		call []byte
+		err  error
+	)
+	if options.Router == SwapRouter02 && options.Deadline != nil {
+		// SwapRouter02 swaps carry no deadline, so it is checked by the multicall
+		call, err = EncodeMulticallWithDeadline(options.Deadline, calldatas)
+	} else {
+		call, err = EncodeMulticall(calldatas)
+	}
 	if err != nil {
 		return nil, err
 	}
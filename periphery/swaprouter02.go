@@ -0,0 +1,205 @@
+package periphery
+
+import (
+	_ "embed"
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed contracts/SwapRouter02.sol/SwapRouter02.json
+var swapRouter02ABI []byte
+
+var ErrMethodNotFound = errors.New("method not found")
+
+// SwapRouterVersion is the router that swap calldata is produced for
+type SwapRouterVersion int
+
+const (
+	SwapRouter01 SwapRouterVersion = iota // The original SwapRouter, whose swap params carry a deadline
+	SwapRouter02                          // SwapRouter02, whose swap params have no deadline and which checks it in multicall
+)
+
+// Recipients that SwapRouter02 replaces with the caller and with the router itself
+var (
+	MsgSender   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	AddressThis = common.HexToAddress("0x0000000000000000000000000000000000000002")
+)
+
+type Router02ExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+type Router02ExactOutputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	AmountOut         *big.Int
+	AmountInMaximum   *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+type Router02ExactInputParams struct {
+	Path             []byte
+	Recipient        common.Address
+	AmountIn         *big.Int
+	AmountOutMinimum *big.Int
+}
+
+type Router02ExactOutputParams struct {
+	Path            []byte
+	Recipient       common.Address
+	AmountOut       *big.Int
+	AmountInMaximum *big.Int
+}
+
+/**
+ * Encodes a multicall to SwapRouter02 that reverts once the deadline has passed
+ * @param deadline When the transaction expires, in epoch seconds
+ * @param calldatas The calls to make
+ */
+func EncodeMulticallWithDeadline(deadline *big.Int, calldatas [][]byte) ([]byte, error) {
+	return PackBySig(GetABI(swapRouter02ABI), "multicall(uint256,bytes[])", deadline, calldatas)
+}
+
+/**
+ * Encodes an unwrapWETH9 call to SwapRouter02. A zero or MsgSender recipient uses the overload without a recipient,
+ * which sends the ETH to the caller.
+ * @param amountMinimum The minimum amount of WETH9 to unwrap
+ * @param recipient The account that should receive the ETH
+ * @param feeOptions Optional information for taking a fee on the ETH
+ */
+func EncodeUnwrapWETH9Router02(amountMinimum *big.Int, recipient common.Address, feeOptions *FeeOptions) ([]byte, error) {
+	abi := GetABI(swapRouter02ABI)
+	toSender := recipient == constants.AddressZero || recipient == MsgSender
+	if feeOptions != nil {
+		if toSender {
+			return PackBySig(abi, "unwrapWETH9WithFee(uint256,uint256,address)", amountMinimum, encodeFeeBips(feeOptions.Fee), feeOptions.Recipient)
+		}
+		return PackBySig(abi, "unwrapWETH9WithFee(uint256,address,uint256,address)", amountMinimum, recipient, encodeFeeBips(feeOptions.Fee), feeOptions.Recipient)
+	}
+	if toSender {
+		return PackBySig(abi, "unwrapWETH9(uint256)", amountMinimum)
+	}
+	return PackBySig(abi, "unwrapWETH9(uint256,address)", amountMinimum, recipient)
+}
+
+/**
+ * Encodes a sweepToken call to SwapRouter02. A zero or MsgSender recipient uses the overload without a recipient,
+ * which sends the tokens to the caller.
+ * @param token The token to sweep
+ * @param amountMinimum The minimum amount of the token to sweep
+ * @param recipient The account that should receive the tokens
+ * @param feeOptions Optional information for taking a fee on the tokens
+ */
+func EncodeSweepTokenRouter02(token *core.Token, amountMinimum *big.Int, recipient common.Address, feeOptions *FeeOptions) ([]byte, error) {
+	abi := GetABI(swapRouter02ABI)
+	toSender := recipient == constants.AddressZero || recipient == MsgSender
+	if feeOptions != nil {
+		if toSender {
+			return PackBySig(abi, "sweepTokenWithFee(address,uint256,uint256,address)", token.Address, amountMinimum, encodeFeeBips(feeOptions.Fee), feeOptions.Recipient)
+		}
+		return PackBySig(abi, "sweepTokenWithFee(address,uint256,address,uint256,address)", token.Address, amountMinimum, recipient, encodeFeeBips(feeOptions.Fee), feeOptions.Recipient)
+	}
+	if toSender {
+		return PackBySig(abi, "sweepToken(address,uint256)", token.Address, amountMinimum)
+	}
+	return PackBySig(abi, "sweepToken(address,uint256,address)", token.Address, amountMinimum, recipient)
+}
+
+/**
+ * Encodes a wrapETH call to SwapRouter02, which wraps ETH held by the router into WETH9
+ * @param value The amount of ETH to wrap
+ */
+func EncodeWrapETH(value *big.Int) ([]byte, error) {
+	return GetABI(swapRouter02ABI).Pack("wrapETH", value)
+}
+
+/**
+ * Encodes a pull call to SwapRouter02, which transfers tokens from the caller to the router
+ * @param token The token to pull
+ * @param value The amount of the token to pull
+ */
+func EncodePull(token *core.Token, value *big.Int) ([]byte, error) {
+	return GetABI(swapRouter02ABI).Pack("pull", token.Address, value)
+}
+
+func encodeExactInputSingle(router SwapRouterVersion, params *ExactInputSingleParams) ([]byte, error) {
+	if router != SwapRouter02 {
+		return GetABI(swapRouterABI).Pack("exactInputSingle", params)
+	}
+	return GetABI(swapRouter02ABI).Pack("exactInputSingle", &Router02ExactInputSingleParams{
+		TokenIn:           params.TokenIn,
+		TokenOut:          params.TokenOut,
+		Fee:               params.Fee,
+		Recipient:         params.Recipient,
+		AmountIn:          params.AmountIn,
+		AmountOutMinimum:  params.AmountOutMinimum,
+		SqrtPriceLimitX96: params.SqrtPriceLimitX96,
+	})
+}
+
+func encodeExactOutputSingle(router SwapRouterVersion, params *ExactOutputSingleParams) ([]byte, error) {
+	if router != SwapRouter02 {
+		return GetABI(swapRouterABI).Pack("exactOutputSingle", params)
+	}
+	return GetABI(swapRouter02ABI).Pack("exactOutputSingle", &Router02ExactOutputSingleParams{
+		TokenIn:           params.TokenIn,
+		TokenOut:          params.TokenOut,
+		Fee:               params.Fee,
+		Recipient:         params.Recipient,
+		AmountOut:         params.AmountOut,
+		AmountInMaximum:   params.AmountInMaximum,
+		SqrtPriceLimitX96: params.SqrtPriceLimitX96,
+	})
+}
+
+func encodeExactInput(router SwapRouterVersion, params *ExactInputParams) ([]byte, error) {
+	if router != SwapRouter02 {
+		return GetABI(swapRouterABI).Pack("exactInput", params)
+	}
+	return GetABI(swapRouter02ABI).Pack("exactInput", &Router02ExactInputParams{
+		Path:             params.Path,
+		Recipient:        params.Recipient,
+		AmountIn:         params.AmountIn,
+		AmountOutMinimum: params.AmountOutMinimum,
+	})
+}
+
+func encodeExactOutput(router SwapRouterVersion, params *ExactOutputParams) ([]byte, error) {
+	if router != SwapRouter02 {
+		return GetABI(swapRouterABI).Pack("exactOutput", params)
+	}
+	return GetABI(swapRouter02ABI).Pack("exactOutput", &Router02ExactOutputParams{
+		Path:            params.Path,
+		Recipient:       params.Recipient,
+		AmountOut:       params.AmountOut,
+		AmountInMaximum: params.AmountInMaximum,
+	})
+}
+
+// PackBySig packs a call to a method by its signature, since go-ethereum names overloaded methods by ABI order
+func PackBySig(contract abi.ABI, sig string, args ...interface{}) ([]byte, error) {
+	for _, method := range contract.Methods {
+		if method.Sig != sig {
+			continue
+		}
+		arguments, err := method.Inputs.Pack(args...)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]byte{}, method.ID...), arguments...), nil
+	}
+	return nil, ErrMethodNotFound
+}
@@ -0,0 +1,210 @@
+package periphery
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeMulticallWithDeadline returns the deadline and the calls of a SwapRouter02 multicall(uint256,bytes[])
+func decodeMulticallWithDeadline(t *testing.T, data []byte) (*big.Int, [][]byte) {
+	assert.Equal(t, "0x5ae401dc", hexutil.Encode(data[:4]))
+	contract := GetABI(swapRouter02ABI)
+	method, err := contract.MethodById(data[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return args[0].(*big.Int), args[1].([][]byte)
+}
+
+func TestSwapCallParametersRouter02(t *testing.T) {
+	pool_0_1 := makePool(token0, token1)
+	pool_1_weth := makePool(token1, weth)
+	slippageTolerance := core.NewPercent(big.NewInt(1), big.NewInt(100))
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	deadline := big.NewInt(123)
+
+	// single-hop exact input
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	trade, _ := entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	params, err := SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, calls := decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, deadline, d)
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, "0x04e45aaf000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000bb80000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000006400000000000000000000000000000000000000000000000000000000000000610000000000000000000000000000000000000000000000000000000000000000", hexutil.Encode(calls[0]))
+	assert.Equal(t, "0x00", utils.ToHex(params.Value))
+
+	// single-hop exact output
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactOutput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, "0x5023b4df000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000bb80000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000006400000000000000000000000000000000000000000000000000000000000000670000000000000000000000000000000000000000000000000000000000000000", hexutil.Encode(calls[0]))
+
+	// multi-hop exact input
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1, pool_1_weth}, token0, weth)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, "0xb858183f0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000064000000000000000000000000000000000000000000000000000000000000005f00000000000000000000000000000000000000000000000000000000000000420000000000000000000000000000000000000001000bb80000000000000000000000000000000000000002000bb8c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2000000000000000000000000000000000000000000000000000000000000", hexutil.Encode(calls[0]))
+
+	// multi-hop exact output
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(weth, big.NewInt(100)), core.ExactOutput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, "0x09b81346", hexutil.Encode(calls[0][:4]))
+
+	// ETH out is swapped to the router and unwrapped to the recipient
+	r, _ = entities.NewRoute([]*entities.Pool{pool_1_weth}, token1, ether)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactInput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "0x04e45aaf", hexutil.Encode(calls[0][:4]))
+	assert.Equal(t, common.LeftPadBytes(AddressThis.Bytes(), 32), calls[0][4+3*32:4+4*32])
+	assert.Equal(t, "0x49404b7c00000000000000000000000000000000000000000000000000000000000000610000000000000000000000000000000000000000000000000000000000000003", hexutil.Encode(calls[1]))
+
+	// ETH out to the caller uses the unwrap without a recipient
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, "0x496169970000000000000000000000000000000000000000000000000000000000000061", hexutil.Encode(calls[1]))
+
+	// ETH in, exact output, refunds the excess ETH
+	r, _ = entities.NewRoute([]*entities.Pool{pool_1_weth}, ether, token1)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactOutput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "0x12210e8a", hexutil.Encode(calls[1]))
+	assert.Equal(t, "0x67", utils.ToHex(params.Value))
+
+	// a fee on token output is swept by the router
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Deadline:          deadline,
+		Router:            SwapRouter02,
+		Fee: &FeeOptions{
+			Fee:       core.NewPercent(big.NewInt(5), big.NewInt(1000)),
+			Recipient: common.HexToAddress("0x0000000000000000000000000000000000000009"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, calls = decodeMulticallWithDeadline(t, params.Calldata)
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "0xe0e189a000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000061000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000000000000000000000000000000000000320000000000000000000000000000000000000000000000000000000000000009", hexutil.Encode(calls[1]))
+
+	// without a deadline the swap is sent on its own
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippageTolerance,
+		Recipient:         recipient,
+		Router:            SwapRouter02,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x04e45aaf", hexutil.Encode(params.Calldata[:4]))
+}
+
+func TestEncodeRouter02Payments(t *testing.T) {
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000003")
+	amount := big.NewInt(123)
+
+	calldata, err := EncodeSweepTokenRouter02(token0, amount, recipient, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xdf2ab5bb0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000007b0000000000000000000000000000000000000000000000000000000000000003", hexutil.Encode(calldata))
+
+	calldata, err = EncodeSweepTokenRouter02(token0, amount, MsgSender, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xe90a182f0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000007b", hexutil.Encode(calldata))
+
+	calldata, err = EncodeWrapETH(amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x1c58db4f000000000000000000000000000000000000000000000000000000000000007b", hexutil.Encode(calldata))
+
+	calldata, err = EncodePull(token0, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xf2d5d56b0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000007b", hexutil.Encode(calldata))
+
+	_, err = PackBySig(GetABI(swapRouter02ABI), "unknown()")
+	assert.Equal(t, ErrMethodNotFound, err)
+}
@@ -0,0 +1,269 @@
+package permit2
+
+import (
+	_ "embed"
+	"math/big"
+
+	"github.com/daoleno/uniswapv3-sdk/periphery"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+//go:embed contracts/Permit2.json
+var permit2ABI []byte
+
+// The address Permit2 is deployed at on every chain
+var Permit2Address = common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+
+var (
+	permitDetailsTypeHash           = crypto.Keccak256([]byte("PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitSingleTypeHash            = crypto.Keccak256([]byte("PermitSingle(PermitDetails details,address spender,uint256 sigDeadline)PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	permitBatchTypeHash             = crypto.Keccak256([]byte("PermitBatch(PermitDetails[] details,address spender,uint256 sigDeadline)PermitDetails(address token,uint160 amount,uint48 expiration,uint48 nonce)"))
+	tokenPermissionsTypeHash        = crypto.Keccak256([]byte("TokenPermissions(address token,uint256 amount)"))
+	permitTransferFromTypeHash      = crypto.Keccak256([]byte("PermitTransferFrom(TokenPermissions permitted,address spender,uint256 nonce,uint256 deadline)TokenPermissions(address token,uint256 amount)"))
+	permitBatchTransferFromTypeHash = crypto.Keccak256([]byte("PermitBatchTransferFrom(TokenPermissions[] permitted,address spender,uint256 nonce,uint256 deadline)TokenPermissions(address token,uint256 amount)"))
+)
+
+// PermitDetails is the allowance of a token granted by an AllowanceTransfer permit
+type PermitDetails struct {
+	Token      common.Address // The token to allow spending of
+	Amount     *big.Int       // The maximum amount allowed to be spent, a uint160
+	Expiration *big.Int       // When the allowance expires, in epoch seconds, a uint48
+	Nonce      *big.Int       // The nonce of the owner's allowance for the token and spender, a uint48
+}
+
+// PermitSingle is an AllowanceTransfer permit for one token
+type PermitSingle struct {
+	Details     PermitDetails
+	Spender     common.Address // The account allowed to spend the token
+	SigDeadline *big.Int       // When the signature expires, in epoch seconds
+}
+
+// PermitBatch is an AllowanceTransfer permit for several tokens
+type PermitBatch struct {
+	Details     []PermitDetails
+	Spender     common.Address // The account allowed to spend the tokens
+	SigDeadline *big.Int       // When the signature expires, in epoch seconds
+}
+
+// TokenPermissions is an amount of a token that a SignatureTransfer permit allows to be transferred
+type TokenPermissions struct {
+	Token  common.Address
+	Amount *big.Int
+}
+
+// PermitTransferFrom is a SignatureTransfer permit for a one time transfer of a token by the spender that calls
+// permitTransferFrom, who is part of the signed data but not of the calldata
+type PermitTransferFrom struct {
+	Permitted TokenPermissions
+	Nonce     *big.Int // An unordered nonce, used once
+	Deadline  *big.Int // When the signature expires, in epoch seconds
+}
+
+// PermitBatchTransferFrom is a SignatureTransfer permit for a one time transfer of several tokens
+type PermitBatchTransferFrom struct {
+	Permitted []TokenPermissions
+	Nonce     *big.Int // An unordered nonce, used once
+	Deadline  *big.Int // When the signature expires, in epoch seconds
+}
+
+// SignatureTransferDetails is where the spender sends the tokens of a SignatureTransfer permit
+type SignatureTransferDetails struct {
+	To              common.Address
+	RequestedAmount *big.Int // The amount to transfer, up to the permitted amount
+}
+
+// Domain returns the EIP-712 domain of Permit2 on a chain
+func Domain(chainID *big.Int) *utils.EIP712Domain {
+	return &utils.EIP712Domain{
+		Name:              "Permit2",
+		ChainID:           chainID,
+		VerifyingContract: Permit2Address,
+	}
+}
+
+// Hash returns the EIP-712 struct hash of the permit details
+func (d *PermitDetails) Hash() []byte {
+	return crypto.Keccak256(
+		permitDetailsTypeHash,
+		utils.EncodeAddress(d.Token),
+		utils.EncodeUint256(d.Amount),
+		utils.EncodeUint256(d.Expiration),
+		utils.EncodeUint256(d.Nonce),
+	)
+}
+
+// Hash returns the EIP-712 struct hash of the permit
+func (p *PermitSingle) Hash() []byte {
+	return crypto.Keccak256(
+		permitSingleTypeHash,
+		p.Details.Hash(),
+		utils.EncodeAddress(p.Spender),
+		utils.EncodeUint256(p.SigDeadline),
+	)
+}
+
+// Hash returns the EIP-712 struct hash of the permit
+func (p *PermitBatch) Hash() []byte {
+	var details []byte
+	for i := range p.Details {
+		details = append(details, p.Details[i].Hash()...)
+	}
+	return crypto.Keccak256(
+		permitBatchTypeHash,
+		crypto.Keccak256(details),
+		utils.EncodeAddress(p.Spender),
+		utils.EncodeUint256(p.SigDeadline),
+	)
+}
+
+// Hash returns the EIP-712 struct hash of the token permissions
+func (t *TokenPermissions) Hash() []byte {
+	return crypto.Keccak256(
+		tokenPermissionsTypeHash,
+		utils.EncodeAddress(t.Token),
+		utils.EncodeUint256(t.Amount),
+	)
+}
+
+// Hash returns the EIP-712 struct hash of the permit for the spender that will call permitTransferFrom
+func (p *PermitTransferFrom) Hash(spender common.Address) []byte {
+	return crypto.Keccak256(
+		permitTransferFromTypeHash,
+		p.Permitted.Hash(),
+		utils.EncodeAddress(spender),
+		utils.EncodeUint256(p.Nonce),
+		utils.EncodeUint256(p.Deadline),
+	)
+}
+
+// Hash returns the EIP-712 struct hash of the permit for the spender that will call permitTransferFrom
+func (p *PermitBatchTransferFrom) Hash(spender common.Address) []byte {
+	var permitted []byte
+	for i := range p.Permitted {
+		permitted = append(permitted, p.Permitted[i].Hash()...)
+	}
+	return crypto.Keccak256(
+		permitBatchTransferFromTypeHash,
+		crypto.Keccak256(permitted),
+		utils.EncodeAddress(spender),
+		utils.EncodeUint256(p.Nonce),
+		utils.EncodeUint256(p.Deadline),
+	)
+}
+
+/**
+ * Signs an AllowanceTransfer permit for one token
+ * @param signer The owner of the token
+ * @param permit The permit to sign
+ * @param chainID The chain the permit is valid on
+ * @returns The 65 byte signature
+ */
+func SignPermitSingle(signer utils.Signer, permit *PermitSingle, chainID *big.Int) ([]byte, error) {
+	return signer.SignHash(utils.TypedDataHash(Domain(chainID), permit.Hash()))
+}
+
+/**
+ * Signs an AllowanceTransfer permit for several tokens
+ * @param signer The owner of the tokens
+ * @param permit The permit to sign
+ * @param chainID The chain the permit is valid on
+ * @returns The 65 byte signature
+ */
+func SignPermitBatch(signer utils.Signer, permit *PermitBatch, chainID *big.Int) ([]byte, error) {
+	return signer.SignHash(utils.TypedDataHash(Domain(chainID), permit.Hash()))
+}
+
+/**
+ * Signs a SignatureTransfer permit for one token
+ * @param signer The owner of the token
+ * @param permit The permit to sign
+ * @param spender The account that will call permitTransferFrom
+ * @param chainID The chain the permit is valid on
+ * @returns The 65 byte signature
+ */
+func SignPermitTransferFrom(signer utils.Signer, permit *PermitTransferFrom, spender common.Address, chainID *big.Int) ([]byte, error) {
+	return signer.SignHash(utils.TypedDataHash(Domain(chainID), permit.Hash(spender)))
+}
+
+/**
+ * Signs a SignatureTransfer permit for several tokens
+ * @param signer The owner of the tokens
+ * @param permit The permit to sign
+ * @param spender The account that will call permitTransferFrom
+ * @param chainID The chain the permit is valid on
+ * @returns The 65 byte signature
+ */
+func SignPermitBatchTransferFrom(signer utils.Signer, permit *PermitBatchTransferFrom, spender common.Address, chainID *big.Int) ([]byte, error) {
+	return signer.SignHash(utils.TypedDataHash(Domain(chainID), permit.Hash(spender)))
+}
+
+/**
+ * Produces the calldata for setting an allowance from a signed permit for one token
+ * @param owner The owner of the token
+ * @param permit The signed permit
+ * @param signature The owner's signature of the permit
+ */
+func EncodePermit(owner common.Address, permit *PermitSingle, signature []byte) ([]byte, error) {
+	abi := periphery.GetABI(permit2ABI)
+	return periphery.PackBySig(abi, "permit(address,((address,uint160,uint48,uint48),address,uint256),bytes)", owner, permit, signature)
+}
+
+/**
+ * Produces the calldata for setting allowances from a signed permit for several tokens
+ * @param owner The owner of the tokens
+ * @param permit The signed permit
+ * @param signature The owner's signature of the permit
+ */
+func EncodePermitBatch(owner common.Address, permit *PermitBatch, signature []byte) ([]byte, error) {
+	abi := periphery.GetABI(permit2ABI)
+	return periphery.PackBySig(abi, "permit(address,((address,uint160,uint48,uint48)[],address,uint256),bytes)", owner, permit, signature)
+}
+
+/**
+ * Produces the calldata for transferring a token with a signed permit
+ * @param permit The signed permit
+ * @param transferDetails Where to send the token
+ * @param owner The owner of the token
+ * @param signature The owner's signature of the permit
+ */
+func EncodePermitTransferFrom(permit *PermitTransferFrom, transferDetails *SignatureTransferDetails, owner common.Address, signature []byte) ([]byte, error) {
+	abi := periphery.GetABI(permit2ABI)
+	return periphery.PackBySig(abi, "permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)", permit, transferDetails, owner, signature)
+}
+
+/**
+ * Produces the calldata for transferring several tokens with a signed permit
+ * @param permit The signed permit
+ * @param transferDetails Where to send each token, in the order of the permitted tokens
+ * @param owner The owner of the tokens
+ * @param signature The owner's signature of the permit
+ */
+func EncodePermitBatchTransferFrom(permit *PermitBatchTransferFrom, transferDetails []SignatureTransferDetails, owner common.Address, signature []byte) ([]byte, error) {
+	abi := periphery.GetABI(permit2ABI)
+	return periphery.PackBySig(abi, "permitTransferFrom(((address,uint256)[],uint256,uint256),(address,uint256)[],address,bytes)", permit, transferDetails, owner, signature)
+}
+
+/**
+ * Produces the calldata for reading the allowance of a spender, whose nonce the next permit must use
+ * @param owner The owner of the token
+ * @param token The token
+ * @param spender The spender
+ */
+func EncodeAllowance(owner, token, spender common.Address) ([]byte, error) {
+	return periphery.GetABI(permit2ABI).Pack("allowance", owner, token, spender)
+}
+
+/**
+ * Decodes the result of an allowance call
+ * @param data The returned data
+ * @returns The allowed amount, its expiration and the nonce of the next permit
+ */
+func DecodeAllowance(data []byte) (amount, expiration, nonce *big.Int, err error) {
+	results, err := periphery.GetABI(permit2ABI).Unpack("allowance", data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return results[0].(*big.Int), results[1].(*big.Int), results[2].(*big.Int), nil
+}
@@ -0,0 +1,226 @@
+package permit2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/internal/testutil"
+	"github.com/daoleno/uniswapv3-sdk/periphery"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	chainID = big.NewInt(1)
+	token0  = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	token1  = common.HexToAddress("0x0000000000000000000000000000000000000002")
+	spender = common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD")
+)
+
+// The domain of Permit2 on mainnet, for hashing typed data with go-ethereum's generic encoder
+var permit2Domain = apitypes.TypedDataDomain{
+	Name:              "Permit2",
+	ChainId:           math.NewHexOrDecimal256(1),
+	VerifyingContract: Permit2Address.Hex(),
+}
+
+var (
+	permitDetailsType = []apitypes.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint160"},
+		{Name: "expiration", Type: "uint48"},
+		{Name: "nonce", Type: "uint48"},
+	}
+	tokenPermissionsType = []apitypes.Type{
+		{Name: "token", Type: "address"},
+		{Name: "amount", Type: "uint256"},
+	}
+)
+
+func TestPermitSingle(t *testing.T) {
+	permit := &PermitSingle{
+		Details:     PermitDetails{Token: token0, Amount: big.NewInt(1000), Expiration: big.NewInt(1700000000), Nonce: big.NewInt(2)},
+		Spender:     spender,
+		SigDeadline: big.NewInt(1690000000),
+	}
+	// go-ethereum's typed data encoder rejects uint160 and uint48, so only the type hash is checked against it
+	typedData := apitypes.TypedData{Types: apitypes.Types{
+		"PermitSingle": {
+			{Name: "details", Type: "PermitDetails"},
+			{Name: "spender", Type: "address"},
+			{Name: "sigDeadline", Type: "uint256"},
+		},
+		"PermitDetails": permitDetailsType,
+	}}
+	assert.Equal(t, []byte(typedData.TypeHash("PermitSingle")), permitSingleTypeHash)
+	assert.Equal(t, []byte(typedData.TypeHash("PermitDetails")), permitDetailsTypeHash)
+	expected := crypto.Keccak256(
+		permitSingleTypeHash,
+		crypto.Keccak256(permitDetailsTypeHash, common.LeftPadBytes(token0.Bytes(), 32), math.U256Bytes(big.NewInt(1000)), math.U256Bytes(big.NewInt(1700000000)), math.U256Bytes(big.NewInt(2))),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		math.U256Bytes(big.NewInt(1690000000)),
+	)
+	assert.Equal(t, expected, permit.Hash())
+	expected = utils.TypedDataHash(Domain(chainID), permit.Hash())
+
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	signature, err := SignPermitSingle(signer, permit, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := utils.RecoverSigner(expected, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), owner)
+
+	calldata, err := EncodePermit(owner, permit, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x2b67b570", hexutil.Encode(calldata[:4]))
+}
+
+func TestPermitBatch(t *testing.T) {
+	permit := &PermitBatch{
+		Details: []PermitDetails{
+			{Token: token0, Amount: big.NewInt(1000), Expiration: big.NewInt(1700000000), Nonce: big.NewInt(2)},
+			{Token: token1, Amount: big.NewInt(2000), Expiration: big.NewInt(1700000000), Nonce: big.NewInt(0)},
+		},
+		Spender:     spender,
+		SigDeadline: big.NewInt(1690000000),
+	}
+	typedData := apitypes.TypedData{Types: apitypes.Types{
+		"PermitBatch": {
+			{Name: "details", Type: "PermitDetails[]"},
+			{Name: "spender", Type: "address"},
+			{Name: "sigDeadline", Type: "uint256"},
+		},
+		"PermitDetails": permitDetailsType,
+	}}
+	assert.Equal(t, []byte(typedData.TypeHash("PermitBatch")), permitBatchTypeHash)
+	expected := crypto.Keccak256(
+		permitBatchTypeHash,
+		crypto.Keccak256(permit.Details[0].Hash(), permit.Details[1].Hash()),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		math.U256Bytes(big.NewInt(1690000000)),
+	)
+	assert.Equal(t, expected, permit.Hash())
+
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	signature, err := SignPermitBatch(signer, permit, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calldata, err := EncodePermitBatch(signer.Address(), permit, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x2a2d80d1", hexutil.Encode(calldata[:4]))
+}
+
+func TestPermitTransferFrom(t *testing.T) {
+	permit := &PermitTransferFrom{
+		Permitted: TokenPermissions{Token: token0, Amount: big.NewInt(1000)},
+		Nonce:     big.NewInt(7),
+		Deadline:  big.NewInt(1690000000),
+	}
+	expected := testutil.TypedDataHash(t, permit2Domain, "PermitTransferFrom", apitypes.Types{
+		"PermitTransferFrom": {
+			{Name: "permitted", Type: "TokenPermissions"},
+			{Name: "spender", Type: "address"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+		"TokenPermissions": tokenPermissionsType,
+	}, apitypes.TypedDataMessage{
+		"permitted": map[string]interface{}{"token": token0.Hex(), "amount": "1000"},
+		"spender":   spender.Hex(),
+		"nonce":     "7",
+		"deadline":  "1690000000",
+	})
+	assert.Equal(t, expected, utils.TypedDataHash(Domain(chainID), permit.Hash(spender)))
+
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	signature, err := SignPermitTransferFrom(signer, permit, spender, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := utils.RecoverSigner(expected, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), owner)
+
+	calldata, err := EncodePermitTransferFrom(permit, &SignatureTransferDetails{To: spender, RequestedAmount: big.NewInt(500)}, owner, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x30f28b7a", hexutil.Encode(calldata[:4]))
+}
+
+func TestPermitBatchTransferFrom(t *testing.T) {
+	permit := &PermitBatchTransferFrom{
+		Permitted: []TokenPermissions{{Token: token0, Amount: big.NewInt(1000)}, {Token: token1, Amount: big.NewInt(2000)}},
+		Nonce:     big.NewInt(7),
+		Deadline:  big.NewInt(1690000000),
+	}
+	expected := testutil.TypedDataHash(t, permit2Domain, "PermitBatchTransferFrom", apitypes.Types{
+		"PermitBatchTransferFrom": {
+			{Name: "permitted", Type: "TokenPermissions[]"},
+			{Name: "spender", Type: "address"},
+			{Name: "nonce", Type: "uint256"},
+			{Name: "deadline", Type: "uint256"},
+		},
+		"TokenPermissions": tokenPermissionsType,
+	}, apitypes.TypedDataMessage{
+		"permitted": []interface{}{
+			map[string]interface{}{"token": token0.Hex(), "amount": "1000"},
+			map[string]interface{}{"token": token1.Hex(), "amount": "2000"},
+		},
+		"spender":  spender.Hex(),
+		"nonce":    "7",
+		"deadline": "1690000000",
+	})
+	assert.Equal(t, expected, utils.TypedDataHash(Domain(chainID), permit.Hash(spender)))
+
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	signature, err := SignPermitBatchTransferFrom(signer, permit, spender, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calldata, err := EncodePermitBatchTransferFrom(permit, []SignatureTransferDetails{
+		{To: spender, RequestedAmount: big.NewInt(1000)},
+		{To: spender, RequestedAmount: big.NewInt(2000)},
+	}, signer.Address(), signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xedd9444b", hexutil.Encode(calldata[:4]))
+}
+
+func TestAllowance(t *testing.T) {
+	calldata, err := EncodeAllowance(spender, token0, spender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x927da105", hexutil.Encode(calldata[:4]))
+
+	contract := periphery.GetABI(permit2ABI)
+	data, err := contract.Methods["allowance"].Outputs.Pack(big.NewInt(1000), big.NewInt(1700000000), big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount, expiration, nonce, err := DecodeAllowance(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, big.NewInt(1000), amount)
+	assert.Equal(t, big.NewInt(1700000000), expiration)
+	assert.Equal(t, big.NewInt(3), nonce)
+}
@@ -0,0 +1,149 @@
+package universalrouter
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/daoleno/uniswapv3-sdk/permit2"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+// CommandType is a command of the Universal Router, the byte that selects how its input is decoded and executed
+type CommandType byte
+
+const (
+	V3SwapExactIn  CommandType = 0x00
+	V3SwapExactOut CommandType = 0x01
+	Sweep          CommandType = 0x04
+	Transfer       CommandType = 0x05
+	PayPortion     CommandType = 0x06
+	Permit2Permit  CommandType = 0x0a
+	WrapETH        CommandType = 0x0b
+	UnwrapWETH     CommandType = 0x0c
+)
+
+const (
+	FlagAllowRevert byte = 0x80 // Set on a command to let the transaction continue if the command reverts
+	CommandTypeMask byte = 0x3f // Masks the flags out of a command
+)
+
+// The token address the router uses for ETH in SWEEP, TRANSFER and PAY_PORTION. Recipients are replaced with the
+// caller and the router itself for periphery.MsgSender and periphery.AddressThis, like in SwapRouter02.
+var ETHAddress = common.HexToAddress("0x0000000000000000000000000000000000000000")
+
+var (
+	addressType, _ = abi.NewType("address", "", nil)
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	bytesType, _   = abi.NewType("bytes", "", nil)
+	boolType, _    = abi.NewType("bool", "", nil)
+
+	permitSingleType, _ = abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "details", Type: "tuple", Components: []abi.ArgumentMarshaling{
+			{Name: "token", Type: "address"},
+			{Name: "amount", Type: "uint160"},
+			{Name: "expiration", Type: "uint48"},
+			{Name: "nonce", Type: "uint48"},
+		}},
+		{Name: "spender", Type: "address"},
+		{Name: "sigDeadline", Type: "uint256"},
+	})
+
+	// The ABI encoding of the input of each command
+	commandArguments = map[CommandType]abi.Arguments{
+		// recipient, amountIn, amountOutMin, path, payerIsUser
+		V3SwapExactIn: {{Type: addressType}, {Type: uint256Type}, {Type: uint256Type}, {Type: bytesType}, {Type: boolType}},
+		// recipient, amountOut, amountInMax, path, payerIsUser
+		V3SwapExactOut: {{Type: addressType}, {Type: uint256Type}, {Type: uint256Type}, {Type: bytesType}, {Type: boolType}},
+		// token, recipient, amountMin
+		Sweep: {{Type: addressType}, {Type: addressType}, {Type: uint256Type}},
+		// token, recipient, value
+		Transfer: {{Type: addressType}, {Type: addressType}, {Type: uint256Type}},
+		// token, recipient, bips
+		PayPortion: {{Type: addressType}, {Type: addressType}, {Type: uint256Type}},
+		// permitSingle, signature
+		Permit2Permit: {{Type: permitSingleType}, {Type: bytesType}},
+		// recipient, amountMin
+		WrapETH: {{Type: addressType}, {Type: uint256Type}},
+		// recipient, amountMin
+		UnwrapWETH: {{Type: addressType}, {Type: uint256Type}},
+	}
+)
+
+// RoutePlanner builds the commands and inputs of a Universal Router execute call
+type RoutePlanner struct {
+	Commands []byte   // One byte per command, the command type with its flags
+	Inputs   [][]byte // The ABI encoded input of each command
+}
+
+func NewRoutePlanner() *RoutePlanner {
+	return &RoutePlanner{}
+}
+
+/**
+ * Appends a command to the plan
+ * @param command The type of the command
+ * @param allowRevert Whether the transaction should continue if the command reverts
+ * @param args The input of the command, in the order the router decodes it
+ */
+func (p *RoutePlanner) AddCommand(command CommandType, allowRevert bool, args ...interface{}) error {
+	arguments, ok := commandArguments[command]
+	if !ok {
+		return ErrUnknownCommand
+	}
+	input, err := arguments.Pack(args...)
+	if err != nil {
+		return err
+	}
+	commandByte := byte(command)
+	if allowRevert {
+		commandByte |= FlagAllowRevert
+	}
+	p.Commands = append(p.Commands, commandByte)
+	p.Inputs = append(p.Inputs, input)
+	return nil
+}
+
+// The typed helpers below each add one command, which lets the transaction continue if it reverts when allowRevert is set
+
+// V3SwapExactIn swaps an exact amount in along a packed path
+func (p *RoutePlanner) V3SwapExactIn(recipient common.Address, amountIn, amountOutMin *big.Int, path []byte, payerIsUser, allowRevert bool) error {
+	return p.AddCommand(V3SwapExactIn, allowRevert, recipient, amountIn, amountOutMin, path, payerIsUser)
+}
+
+// V3SwapExactOut swaps for an exact amount out along a packed path, which is in reverse order
+func (p *RoutePlanner) V3SwapExactOut(recipient common.Address, amountOut, amountInMax *big.Int, path []byte, payerIsUser, allowRevert bool) error {
+	return p.AddCommand(V3SwapExactOut, allowRevert, recipient, amountOut, amountInMax, path, payerIsUser)
+}
+
+// Sweep sends the router's whole balance of a token, at least a minimum, to a recipient
+func (p *RoutePlanner) Sweep(token, recipient common.Address, amountMin *big.Int, allowRevert bool) error {
+	return p.AddCommand(Sweep, allowRevert, token, recipient, amountMin)
+}
+
+// Transfer sends an amount of a token held by the router to a recipient
+func (p *RoutePlanner) Transfer(token, recipient common.Address, value *big.Int, allowRevert bool) error {
+	return p.AddCommand(Transfer, allowRevert, token, recipient, value)
+}
+
+// PayPortion sends a portion, in bips, of the router's balance of a token to a recipient
+func (p *RoutePlanner) PayPortion(token, recipient common.Address, bips *big.Int, allowRevert bool) error {
+	return p.AddCommand(PayPortion, allowRevert, token, recipient, bips)
+}
+
+// Permit2Permit sets the router's Permit2 allowance from a signed permit
+func (p *RoutePlanner) Permit2Permit(permit *permit2.PermitSingle, signature []byte, allowRevert bool) error {
+	return p.AddCommand(Permit2Permit, allowRevert, permit, signature)
+}
+
+// WrapETH wraps the router's ETH, at least a minimum, into WETH for a recipient
+func (p *RoutePlanner) WrapETH(recipient common.Address, amountMin *big.Int, allowRevert bool) error {
+	return p.AddCommand(WrapETH, allowRevert, recipient, amountMin)
+}
+
+// UnwrapWETH unwraps the router's WETH, at least a minimum, into ETH for a recipient
+func (p *RoutePlanner) UnwrapWETH(recipient common.Address, amountMin *big.Int, allowRevert bool) error {
+	return p.AddCommand(UnwrapWETH, allowRevert, recipient, amountMin)
+}
@@ -0,0 +1,75 @@
+package universalrouter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/periphery"
+	"github.com/daoleno/uniswapv3-sdk/permit2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddCommand(t *testing.T) {
+	planner := NewRoutePlanner()
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000003")
+
+	err := planner.WrapETH(periphery.AddressThis, big.NewInt(100), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = planner.AddCommand(Sweep, true, common.HexToAddress("0x0000000000000000000000000000000000000004"), recipient, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{0x0b, 0x84}, planner.Commands)
+	assert.Equal(t, "0x00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000064", hexutil.Encode(planner.Inputs[0]))
+	assert.Equal(t, "0x000000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000001", hexutil.Encode(planner.Inputs[1]))
+	assert.Equal(t, Sweep, CommandType(planner.Commands[1]&CommandTypeMask))
+
+	// inputs must match the command
+	err = planner.AddCommand(Transfer, false, recipient)
+	assert.Error(t, err)
+	err = planner.AddCommand(CommandType(0x3f), false)
+	assert.Equal(t, ErrUnknownCommand, err)
+	assert.Equal(t, 2, len(planner.Commands))
+	assert.Equal(t, 2, len(planner.Inputs))
+}
+
+func TestPermit2PermitCommand(t *testing.T) {
+	planner := NewRoutePlanner()
+	permit := &permit2.PermitSingle{
+		Details: permit2.PermitDetails{
+			Token:      common.HexToAddress("0x0000000000000000000000000000000000000001"),
+			Amount:     big.NewInt(1000),
+			Expiration: big.NewInt(2000),
+			Nonce:      big.NewInt(3),
+		},
+		Spender:     UniversalRouterAddress,
+		SigDeadline: big.NewInt(4000),
+	}
+	signature := make([]byte, 65)
+	signature[64] = 27
+	err := planner.Permit2Permit(permit, signature, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = planner.Permit2Permit(permit, signature, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []byte{0x0a, 0x8a}, planner.Commands)
+	assert.Equal(t, planner.Inputs[0], planner.Inputs[1])
+
+	args, err := commandArguments[Permit2Permit].Unpack(planner.Inputs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signature, args[1])
+	// the permit is static, so it is encoded in place before the offset of the signature
+	input := planner.Inputs[0]
+	assert.Equal(t, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32), input[32:64])
+	assert.Equal(t, common.LeftPadBytes(UniversalRouterAddress.Bytes(), 32), input[128:160])
+	assert.Equal(t, common.LeftPadBytes(big.NewInt(4000).Bytes(), 32), input[160:192])
+}
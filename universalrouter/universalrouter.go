@@ -0,0 +1,235 @@
+package universalrouter
+
+import (
+	_ "embed"
+	"errors"
+	"math/big"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/periphery"
+	"github.com/daoleno/uniswapv3-sdk/permit2"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed contracts/UniversalRouter.json
+var universalRouterABI []byte
+
+var (
+	ErrNoTrades       = errors.New("no trades")
+	ErrNonTokenPermit = errors.New("NON_TOKEN_PERMIT")
+	ErrPermitMismatch = errors.New("permit does not match input token")
+)
+
+var UniversalRouterAddress = common.HexToAddress("0x3fC91A3afd70395Cd496C647d5a6CC9D4B2b7FAD")
+
+// A signed Permit2 permit for the router to spend the input token
+type Permit2PermitOptions struct {
+	permit2.PermitSingle
+	Signature   []byte // The owner's signature of the permit
+	AllowRevert bool   // Whether the swap should go ahead if the permit reverts, e.g. because it was already used
+}
+
+/**
+ * Signs a Permit2 permit for the router to spend a token, to pass as the input token permit of a swap
+ * @param signer The owner of the token
+ * @param permit The permit, whose spender should be the router
+ * @param chainID The chain the permit is valid on
+ */
+func SignPermit2Permit(signer utils.Signer, permit *permit2.PermitSingle, chainID *big.Int) (*Permit2PermitOptions, error) {
+	signature, err := permit2.SignPermitSingle(signer, permit, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return &Permit2PermitOptions{PermitSingle: *permit, Signature: signature}, nil
+}
+
+// Options for producing the arguments to send calls to the Universal Router.
+type SwapOptions struct {
+	SlippageTolerance *core.Percent         // How much the execution price is allowed to move unfavorably from the trade execution price.
+	Recipient         common.Address        // The account that should receive the output, the caller if zero.
+	Deadline          *big.Int              // The optional time the transaction expires, in epoch seconds.
+	InputTokenPermit  *Permit2PermitOptions // The optional Permit2 permit for spending the input.
+	Fee               *periphery.FeeOptions // Optional information for taking a fee on output.
+}
+
+/**
+ * Plans the commands for swapping a set of trades with the same input and output through the Universal Router.
+ * ETH input is wrapped by the router and any excess returned, and ETH output is unwrapped by the router.
+ * @param planner The planner to add the commands to
+ * @param trades The trades to swap
+ * @param options Options for the swap
+ */
+func AddSwapCommands(planner *RoutePlanner, trades []*entities.Trade, options *SwapOptions) error {
+	if len(trades) == 0 {
+		return ErrNoTrades
+	}
+	sampleTrade := trades[0]
+	inputCurrency := sampleTrade.InputAmount().Currency
+	outputCurrency := sampleTrade.OutputAmount().Currency
+	tokenIn := inputCurrency.Wrapped()
+	tokenOut := outputCurrency.Wrapped()
+	for _, trade := range trades {
+		if !trade.InputAmount().Currency.Wrapped().Equal(tokenIn) {
+			return periphery.ErrTokenInDiff
+		}
+		if !trade.OutputAmount().Currency.Wrapped().Equal(tokenOut) {
+			return periphery.ErrTokenOutDiff
+		}
+	}
+
+	totalAmountIn, totalAmountOut, err := totalAmounts(trades, options.SlippageTolerance)
+	if err != nil {
+		return err
+	}
+
+	inputIsNative := inputCurrency.IsNative()
+	outputIsNative := outputCurrency.IsNative()
+	routerMustCustody := outputIsNative || options.Fee != nil
+
+	if options.InputTokenPermit != nil {
+		if inputIsNative {
+			return ErrNonTokenPermit
+		}
+		if options.InputTokenPermit.Details.Token != tokenIn.Address {
+			return ErrPermitMismatch
+		}
+		if err := planner.Permit2Permit(&options.InputTokenPermit.PermitSingle, options.InputTokenPermit.Signature, options.InputTokenPermit.AllowRevert); err != nil {
+			return err
+		}
+	}
+
+	// ETH is wrapped by the router, which then pays for the swaps itself
+	payerIsUser := !inputIsNative
+	if inputIsNative {
+		if err := planner.WrapETH(periphery.AddressThis, totalAmountIn.Quotient(), false); err != nil {
+			return err
+		}
+	}
+
+	recipient := options.Recipient
+	if recipient == constants.AddressZero {
+		recipient = periphery.MsgSender
+	}
+	swapRecipient := recipient
+	if routerMustCustody {
+		swapRecipient = periphery.AddressThis
+	}
+
+	for _, trade := range trades {
+		for _, swap := range trade.Swaps {
+			amountIn, err := trade.MaximumAmountIn(options.SlippageTolerance, swap.InputAmount)
+			if err != nil {
+				return err
+			}
+			amountOut, err := trade.MinimumAmountOut(options.SlippageTolerance, swap.OutputAmount)
+			if err != nil {
+				return err
+			}
+			path, err := periphery.EncodeRouteToPath(swap.Route, trade.TradeType == core.ExactOutput)
+			if err != nil {
+				return err
+			}
+			if trade.TradeType == core.ExactInput {
+				err = planner.V3SwapExactIn(swapRecipient, amountIn.Quotient(), amountOut.Quotient(), path, payerIsUser, false)
+			} else {
+				err = planner.V3SwapExactOut(swapRecipient, amountOut.Quotient(), amountIn.Quotient(), path, payerIsUser, false)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if routerMustCustody {
+		minimumOut := totalAmountOut
+		if options.Fee != nil {
+			bips := options.Fee.Fee.Multiply(core.NewPercent(big.NewInt(10000), constants.One)).Quotient()
+			if err := planner.PayPortion(tokenOut.Address, options.Fee.Recipient, bips, false); err != nil {
+				return err
+			}
+			fee := totalAmountOut.Multiply(options.Fee.Fee.Fraction)
+			minimumOut = totalAmountOut.Subtract(core.FromRawAmount(outputCurrency, fee.Quotient()))
+		}
+		if outputIsNative {
+			err = planner.UnwrapWETH(recipient, minimumOut.Quotient(), false)
+		} else {
+			err = planner.Sweep(tokenOut.Address, recipient, minimumOut.Quotient(), false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// the WETH left over from an exact output swap is returned as ETH
+	if inputIsNative && sampleTrade.TradeType == core.ExactOutput {
+		if err := planner.UnwrapWETH(periphery.MsgSender, constants.Zero, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Produces the Universal Router calldata for swapping a set of trades with the same input and output.
+ * @param trades The trades to swap
+ * @param options Options for the swap
+ * @returns The call parameters, with the ETH to send for ETH input
+ */
+func SwapCallParameters(trades []*entities.Trade, options *SwapOptions) (*utils.MethodParameters, error) {
+	planner := NewRoutePlanner()
+	if err := AddSwapCommands(planner, trades, options); err != nil {
+		return nil, err
+	}
+	calldata, err := EncodeExecute(planner, options.Deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	value := constants.Zero
+	if trades[0].InputAmount().Currency.IsNative() {
+		totalAmountIn, _, err := totalAmounts(trades, options.SlippageTolerance)
+		if err != nil {
+			return nil, err
+		}
+		value = totalAmountIn.Quotient()
+	}
+	return &utils.MethodParameters{
+		Calldata: calldata,
+		Value:    value,
+	}, nil
+}
+
+/**
+ * Encodes a call to execute the planned commands
+ * @param planner The planned commands
+ * @param deadline The optional time the transaction expires, in epoch seconds
+ */
+func EncodeExecute(planner *RoutePlanner, deadline *big.Int) ([]byte, error) {
+	contract := periphery.GetABI(universalRouterABI)
+	if deadline != nil {
+		return periphery.PackBySig(contract, "execute(bytes,bytes[],uint256)", planner.Commands, planner.Inputs, deadline)
+	}
+	return periphery.PackBySig(contract, "execute(bytes,bytes[])", planner.Commands, planner.Inputs)
+}
+
+// totalAmounts returns the maximum total input and minimum total output of a set of trades
+func totalAmounts(trades []*entities.Trade, slippageTolerance *core.Percent) (*core.CurrencyAmount, *core.CurrencyAmount, error) {
+	totalAmountIn := core.FromRawAmount(trades[0].InputAmount().Currency, constants.Zero)
+	totalAmountOut := core.FromRawAmount(trades[0].OutputAmount().Currency, constants.Zero)
+	for _, trade := range trades {
+		maxIn, err := trade.MaximumAmountIn(slippageTolerance, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		minOut, err := trade.MinimumAmountOut(slippageTolerance, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		totalAmountIn = totalAmountIn.Add(maxIn)
+		totalAmountOut = totalAmountOut.Add(minOut)
+	}
+	return totalAmountIn, totalAmountOut, nil
+}
@@ -0,0 +1,197 @@
+package universalrouter
+
+import (
+	"math/big"
+	"testing"
+
+	core "github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/daoleno/uniswapv3-sdk/entities"
+	"github.com/daoleno/uniswapv3-sdk/internal/testutil"
+	"github.com/daoleno/uniswapv3-sdk/periphery"
+	"github.com/daoleno/uniswapv3-sdk/permit2"
+	"github.com/daoleno/uniswapv3-sdk/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ether  = core.EtherOnChain(1)
+	weth   = ether.Wrapped()
+	token0 = core.NewToken(1, common.HexToAddress("0x0000000000000000000000000000000000000001"), 18, "t0", "token0")
+	token1 = core.NewToken(1, common.HexToAddress("0x0000000000000000000000000000000000000002"), 18, "t1", "token1")
+
+	recipient = common.HexToAddress("0x0000000000000000000000000000000000000003")
+	slippage  = core.NewPercent(big.NewInt(1), big.NewInt(100))
+	deadline  = big.NewInt(123)
+)
+
+func makePool(t *testing.T, tokenA, tokenB *core.Token) *entities.Pool {
+	liquidity := big.NewInt(1_000_000)
+	spacing := constants.TickSpacings[constants.FeeMedium]
+	ticks := []entities.Tick{
+		{Index: entities.NearestUsableTick(utils.MinTick, spacing), LiquidityNet: liquidity, LiquidityGross: liquidity},
+		{Index: entities.NearestUsableTick(utils.MaxTick, spacing), LiquidityNet: new(big.Int).Neg(liquidity), LiquidityGross: liquidity},
+	}
+	provider, err := entities.NewTickListDataProvider(ticks, spacing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err := entities.NewPool(tokenA, tokenB, constants.FeeMedium, utils.EncodeSqrtRatioX96(constants.One, constants.One), liquidity, 0, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+// decodeExecute returns the commands, inputs and deadline of an execute call
+func decodeExecute(t *testing.T, data []byte) ([]byte, [][]byte, *big.Int) {
+	contract := periphery.GetABI(universalRouterABI)
+	method, err := contract.MethodById(data[:4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) == 3 {
+		return args[0].([]byte), args[1].([][]byte), args[2].(*big.Int)
+	}
+	return args[0].([]byte), args[1].([][]byte), nil
+}
+
+func TestSwapCallParameters(t *testing.T) {
+	pool_0_1 := makePool(t, token0, token1)
+	pool_1_weth := makePool(t, token1, weth)
+
+	// single-hop exact input
+	r, _ := entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	trade, _ := entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	params, err := SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		Recipient:         recipient,
+		Deadline:          deadline,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x3593564c", hexutil.Encode(params.Calldata[:4]))
+	commands, inputs, d := decodeExecute(t, params.Calldata)
+	assert.Equal(t, []byte{byte(V3SwapExactIn)}, commands)
+	assert.Equal(t, deadline, d)
+	args, err := commandArguments[V3SwapExactIn].Unpack(inputs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, recipient, args[0])
+	assert.Equal(t, big.NewInt(100), args[1])
+	assert.Equal(t, big.NewInt(97), args[2])
+	assert.Equal(t, "0x0000000000000000000000000000000000000001000bb80000000000000000000000000000000000000002", hexutil.Encode(args[3].([]byte)))
+	assert.Equal(t, true, args[4])
+	assert.Equal(t, "0x00", utils.ToHex(params.Value))
+
+	// multi-hop exact output with ETH in wraps the input and refunds the excess
+	r, _ = entities.NewRoute([]*entities.Pool{pool_1_weth, pool_0_1}, ether, token0)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactOutput)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		Recipient:         recipient,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x24856bc3", hexutil.Encode(params.Calldata[:4]))
+	commands, inputs, d = decodeExecute(t, params.Calldata)
+	assert.Nil(t, d)
+	assert.Equal(t, []byte{byte(WrapETH), byte(V3SwapExactOut), byte(UnwrapWETH)}, commands)
+	maxIn, _ := trade.MaximumAmountIn(slippage, nil)
+	assert.Equal(t, maxIn.Quotient(), params.Value)
+	args, _ = commandArguments[V3SwapExactOut].Unpack(inputs[1])
+	assert.Equal(t, recipient, args[0])
+	assert.Equal(t, big.NewInt(100), args[1])
+	assert.Equal(t, maxIn.Quotient(), args[2])
+	assert.Equal(t, "0x0000000000000000000000000000000000000001000bb80000000000000000000000000000000000000002000bb8c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", hexutil.Encode(args[3].([]byte)))
+	assert.Equal(t, false, args[4])
+	args, _ = commandArguments[UnwrapWETH].Unpack(inputs[2])
+	assert.Equal(t, periphery.MsgSender, args[0])
+
+	// ETH out with a fee is swapped to the router, which pays the fee and unwraps the rest
+	r, _ = entities.NewRoute([]*entities.Pool{pool_1_weth}, token1, ether)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token1, big.NewInt(100)), core.ExactInput)
+	feeRecipient := common.HexToAddress("0x0000000000000000000000000000000000000009")
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		Deadline:          deadline,
+		Fee: &periphery.FeeOptions{
+			Fee:       core.NewPercent(big.NewInt(5), big.NewInt(100)),
+			Recipient: feeRecipient,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, inputs, _ = decodeExecute(t, params.Calldata)
+	assert.Equal(t, []byte{byte(V3SwapExactIn), byte(PayPortion), byte(UnwrapWETH)}, commands)
+	args, _ = commandArguments[V3SwapExactIn].Unpack(inputs[0])
+	assert.Equal(t, periphery.AddressThis, args[0])
+	args, _ = commandArguments[PayPortion].Unpack(inputs[1])
+	assert.Equal(t, weth.Address, args[0])
+	assert.Equal(t, feeRecipient, args[1])
+	assert.Equal(t, big.NewInt(500), args[2])
+	args, _ = commandArguments[UnwrapWETH].Unpack(inputs[2])
+	assert.Equal(t, periphery.MsgSender, args[0])
+	assert.Equal(t, big.NewInt(93), args[1])
+
+	// a Permit2 permit comes first
+	r, _ = entities.NewRoute([]*entities.Pool{pool_0_1}, token0, token1)
+	trade, _ = entities.FromRoute(r, core.FromRawAmount(token0, big.NewInt(100)), core.ExactInput)
+	signer := utils.NewPrivateKeySigner(testutil.PrivateKey)
+	permit, err := SignPermit2Permit(signer, &permit2.PermitSingle{
+		Details:     permit2.PermitDetails{Token: token0.Address, Amount: big.NewInt(100), Expiration: big.NewInt(1000), Nonce: big.NewInt(0)},
+		Spender:     UniversalRouterAddress,
+		SigDeadline: big.NewInt(1000),
+	}, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := utils.RecoverSigner(utils.TypedDataHash(permit2.Domain(big.NewInt(1)), permit.Hash()), permit.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), owner)
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		Recipient:         recipient,
+		InputTokenPermit:  permit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, _, _ = decodeExecute(t, params.Calldata)
+	assert.Equal(t, []byte{byte(Permit2Permit), byte(V3SwapExactIn)}, commands)
+
+	// the permit may be allowed to revert, e.g. if it was already used
+	permit.AllowRevert = true
+	params, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		Recipient:         recipient,
+		InputTokenPermit:  permit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands, _, _ = decodeExecute(t, params.Calldata)
+	assert.Equal(t, []byte{byte(Permit2Permit) | FlagAllowRevert, byte(V3SwapExactIn)}, commands)
+
+	permit.Details.Token = token1.Address
+	_, err = SwapCallParameters([]*entities.Trade{trade}, &SwapOptions{
+		SlippageTolerance: slippage,
+		InputTokenPermit:  permit,
+	})
+	assert.Equal(t, ErrPermitMismatch, err)
+
+	_, err = SwapCallParameters(nil, &SwapOptions{SlippageTolerance: slippage})
+	assert.Equal(t, ErrNoTrades, err)
+}
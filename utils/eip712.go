@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// EIP712Domain is the domain that typed data is signed for
+type EIP712Domain struct {
+	Name              string         // The name of the signing domain, e.g. the token name
+	Version           string         // The version of the signing domain, left out of the domain type if empty
+	ChainID           *big.Int       // The chain the signature is valid on
+	VerifyingContract common.Address // The contract that verifies the signature
+}
+
+/**
+ * Returns the EIP-712 domain separator
+ * @returns The hash of the domain
+ */
+func (d *EIP712Domain) Separator() []byte {
+	if d.Version == "" {
+		return crypto.Keccak256(
+			crypto.Keccak256([]byte("EIP712Domain(string name,uint256 chainId,address verifyingContract)")),
+			crypto.Keccak256([]byte(d.Name)),
+			EncodeUint256(d.ChainID),
+			EncodeAddress(d.VerifyingContract),
+		)
+	}
+	return crypto.Keccak256(
+		crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)")),
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		EncodeUint256(d.ChainID),
+		EncodeAddress(d.VerifyingContract),
+	)
+}
+
+/**
+ * Returns the EIP-712 digest of a struct to sign in a domain
+ * @param domain The domain the struct is signed for
+ * @param structHash The hash of the struct
+ * @returns The digest to sign
+ */
+func TypedDataHash(domain *EIP712Domain, structHash []byte) []byte {
+	return crypto.Keccak256([]byte("\x19\x01"), domain.Separator(), structHash)
+}
+
+// EncodeUint256 returns the 32 byte ABI encoding of an unsigned integer
+func EncodeUint256(i *big.Int) []byte {
+	return common.LeftPadBytes(i.Bytes(), 32)
+}
+
+// EncodeAddress returns the 32 byte ABI encoding of an address
+func EncodeAddress(address common.Address) []byte {
+	return common.LeftPadBytes(address.Bytes(), 32)
+}
+
+// Signer signs digests, such as EIP-712 typed data hashes, on behalf of an account
+type Signer interface {
+	Address() common.Address              // The account that signs
+	SignHash(hash []byte) ([]byte, error) // Returns the 65 byte [R || S || V] signature of a digest, with V 27 or 28
+}
+
+// PrivateKeySigner is a Signer holding the private key of the account
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *PrivateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+/**
+ * Splits a 65 byte [R || S || V] signature into its parts
+ * @param signature The signature, with V 0, 1, 27 or 28
+ * @returns V as 27 or 28, R and S
+ */
+func SplitSignature(signature []byte) (uint8, [32]byte, [32]byte, error) {
+	var r, s [32]byte
+	if len(signature) != crypto.SignatureLength {
+		return 0, r, s, ErrInvalidSignature
+	}
+	v := signature[crypto.RecoveryIDOffset]
+	if v < 27 {
+		v += 27
+	}
+	if v != 27 && v != 28 {
+		return 0, r, s, ErrInvalidSignature
+	}
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	return v, r, s, nil
+}
+
+/**
+ * Recovers the account that signed a digest
+ * @param hash The digest that was signed
+ * @param signature The 65 byte [R || S || V] signature, with V 0, 1, 27 or 28
+ * @returns The address of the signer
+ */
+func RecoverSigner(hash, signature []byte) (common.Address, error) {
+	v, r, s, err := SplitSignature(signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	sig := append(append(r[:], s[:]...), v-27)
+	pubkey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/internal/testutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEIP712DomainSeparator(t *testing.T) {
+	verifyingContract := common.HexToAddress("0xC36442b4a4522E871399CD717aBDD847Ab11FE88")
+	typedDataDomain := apitypes.TypedDataDomain{
+		Name:              "Uniswap V3 Positions NFT-V1",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: verifyingContract.Hex(),
+	}
+	domain := &EIP712Domain{
+		Name:              "Uniswap V3 Positions NFT-V1",
+		Version:           "1",
+		ChainID:           big.NewInt(1),
+		VerifyingContract: verifyingContract,
+	}
+	assert.Equal(t, testutil.DomainSeparator(t, typedDataDomain), domain.Separator())
+
+	// without a version the field is left out of the domain type
+	typedDataDomain.Version = ""
+	domain.Version = ""
+	assert.Equal(t, testutil.DomainSeparator(t, typedDataDomain), domain.Separator())
+}
+
+func TestTypedDataHash(t *testing.T) {
+	verifyingContract := common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	types := apitypes.Types{"Mail": {{Name: "to", Type: "address"}, {Name: "contents", Type: "string"}}}
+	message := apitypes.TypedDataMessage{"to": verifyingContract.Hex(), "contents": "hello"}
+	structHash := crypto.Keccak256(
+		crypto.Keccak256([]byte("Mail(address to,string contents)")),
+		EncodeAddress(verifyingContract),
+		crypto.Keccak256([]byte("hello")),
+	)
+	expected := testutil.TypedDataHash(t, apitypes.TypedDataDomain{
+		Name:              "Permit2",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: verifyingContract.Hex(),
+	}, "Mail", types, message)
+	domain := &EIP712Domain{Name: "Permit2", ChainID: big.NewInt(1), VerifyingContract: verifyingContract}
+	assert.Equal(t, expected, TypedDataHash(domain, structHash))
+}
+
+func TestSignAndRecover(t *testing.T) {
+	key := testutil.PrivateKey
+	signer := NewPrivateKeySigner(key)
+	hash := crypto.Keccak256([]byte("hash"))
+
+	signature, err := signer.SignHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 65, len(signature))
+	v, r, s, err := SplitSignature(signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, v == 27 || v == 28)
+	assert.Equal(t, signature[:32], r[:])
+	assert.Equal(t, signature[32:64], s[:])
+
+	recovered, err := RecoverSigner(hash, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), recovered)
+	assert.Equal(t, signer.Address(), recovered)
+
+	// V of 0 or 1 is accepted too
+	signature[64] -= 27
+	recovered, err = RecoverSigner(hash, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, signer.Address(), recovered)
+
+	signature[64] = 5
+	_, err = RecoverSigner(hash, signature)
+	assert.Equal(t, ErrInvalidSignature, err)
+	_, err = RecoverSigner(hash, signature[:64])
+	assert.Equal(t, ErrInvalidSignature, err)
+}
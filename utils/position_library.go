@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+)
+
+/**
+ * Computes the fees earned by liquidity since the fee growth inside its range was last recorded, like the pool does
+ * @param feeGrowthInside0LastX128 The fee growth inside the range of token0 when last recorded
+ * @param feeGrowthInside1LastX128 The fee growth inside the range of token1 when last recorded
+ * @param liquidity The liquidity earning the fees
+ * @param feeGrowthInside0X128 The current fee growth inside the range of token0
+ * @param feeGrowthInside1X128 The current fee growth inside the range of token1
+ * @returns The fees earned of token0 and of token1
+ */
+func GetTokensOwed(feeGrowthInside0LastX128, feeGrowthInside1LastX128, liquidity, feeGrowthInside0X128, feeGrowthInside1X128 *big.Int) (*big.Int, *big.Int) {
+	tokensOwed0 := new(big.Int).Div(new(big.Int).Mul(subIn256(feeGrowthInside0X128, feeGrowthInside0LastX128), liquidity), constants.Q128)
+	tokensOwed1 := new(big.Int).Div(new(big.Int).Mul(subIn256(feeGrowthInside1X128, feeGrowthInside1LastX128), liquidity), constants.Q128)
+	return tokensOwed0, tokensOwed1
+}
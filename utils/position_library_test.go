@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTokensOwed(t *testing.T) {
+	tokensOwed0, tokensOwed1 := GetTokensOwed(constants.Zero, constants.Zero, constants.Zero, constants.Zero, constants.Zero)
+	assert.Equal(t, 0, tokensOwed0.Sign())
+	assert.Equal(t, 0, tokensOwed1.Sign())
+
+	tokensOwed0, tokensOwed1 = GetTokensOwed(constants.Zero, constants.Zero, constants.One, constants.Q128, constants.Q128)
+	assert.Equal(t, constants.One, tokensOwed0)
+	assert.Equal(t, constants.One, tokensOwed1)
+
+	// fee growth that wrapped around since it was recorded
+	tokensOwed0, _ = GetTokensOwed(entities.MaxUint256, constants.Zero, big.NewInt(2), new(big.Int).Sub(constants.Q128, constants.One), constants.Zero)
+	assert.Equal(t, big.NewInt(2), tokensOwed0)
+}
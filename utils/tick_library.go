@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/daoleno/uniswap-sdk-core/entities"
+)
+
+// The fee growth on the other side of a tick from the current tick, as stored in the pool's ticks
+type FeeGrowthOutside struct {
+	FeeGrowthOutside0X128 *big.Int
+	FeeGrowthOutside1X128 *big.Int
+}
+
+func subIn256(x, y *big.Int) *big.Int {
+	difference := new(big.Int).Sub(x, y)
+	return new(big.Int).And(difference, entities.MaxUint256)
+}
+
+/**
+ * Computes the fee growth per unit of liquidity inside a tick range, like the pool does
+ * @param feeGrowthOutsideLower The fee growth outside the lower tick
+ * @param feeGrowthOutsideUpper The fee growth outside the upper tick
+ * @param tickLower The lower tick of the range
+ * @param tickUpper The upper tick of the range
+ * @param tickCurrent The current tick of the pool
+ * @param feeGrowthGlobal0X128 The pool's global fee growth of token0
+ * @param feeGrowthGlobal1X128 The pool's global fee growth of token1
+ * @returns The fee growth inside the range of token0 and of token1
+ */
+func GetFeeGrowthInside(feeGrowthOutsideLower, feeGrowthOutsideUpper *FeeGrowthOutside, tickLower, tickUpper, tickCurrent int, feeGrowthGlobal0X128, feeGrowthGlobal1X128 *big.Int) (*big.Int, *big.Int) {
+	var feeGrowthBelow0X128, feeGrowthBelow1X128 *big.Int
+	if tickCurrent >= tickLower {
+		feeGrowthBelow0X128 = feeGrowthOutsideLower.FeeGrowthOutside0X128
+		feeGrowthBelow1X128 = feeGrowthOutsideLower.FeeGrowthOutside1X128
+	} else {
+		feeGrowthBelow0X128 = subIn256(feeGrowthGlobal0X128, feeGrowthOutsideLower.FeeGrowthOutside0X128)
+		feeGrowthBelow1X128 = subIn256(feeGrowthGlobal1X128, feeGrowthOutsideLower.FeeGrowthOutside1X128)
+	}
+
+	var feeGrowthAbove0X128, feeGrowthAbove1X128 *big.Int
+	if tickCurrent < tickUpper {
+		feeGrowthAbove0X128 = feeGrowthOutsideUpper.FeeGrowthOutside0X128
+		feeGrowthAbove1X128 = feeGrowthOutsideUpper.FeeGrowthOutside1X128
+	} else {
+		feeGrowthAbove0X128 = subIn256(feeGrowthGlobal0X128, feeGrowthOutsideUpper.FeeGrowthOutside0X128)
+		feeGrowthAbove1X128 = subIn256(feeGrowthGlobal1X128, feeGrowthOutsideUpper.FeeGrowthOutside1X128)
+	}
+
+	return subIn256(subIn256(feeGrowthGlobal0X128, feeGrowthBelow0X128), feeGrowthAbove0X128),
+		subIn256(subIn256(feeGrowthGlobal1X128, feeGrowthBelow1X128), feeGrowthAbove1X128)
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daoleno/uniswapv3-sdk/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFeeGrowthInside(t *testing.T) {
+	zero := &FeeGrowthOutside{FeeGrowthOutside0X128: constants.Zero, FeeGrowthOutside1X128: constants.Zero}
+
+	feeGrowthInside0X128, feeGrowthInside1X128 := GetFeeGrowthInside(zero, zero, -1, 1, 0, constants.Zero, constants.Zero)
+	assert.Equal(t, 0, feeGrowthInside0X128.Sign())
+	assert.Equal(t, 0, feeGrowthInside1X128.Sign())
+
+	// all inside
+	feeGrowthInside0X128, feeGrowthInside1X128 = GetFeeGrowthInside(zero, zero, -1, 1, 0, constants.Q128, constants.Q128)
+	assert.Equal(t, constants.Q128, feeGrowthInside0X128)
+	assert.Equal(t, constants.Q128, feeGrowthInside1X128)
+
+	// all outside
+	all := &FeeGrowthOutside{FeeGrowthOutside0X128: constants.Q128, FeeGrowthOutside1X128: constants.Q128}
+	feeGrowthInside0X128, feeGrowthInside1X128 = GetFeeGrowthInside(all, zero, -1, 1, 0, constants.Q128, constants.Q128)
+	assert.Equal(t, 0, feeGrowthInside0X128.Sign())
+	assert.Equal(t, 0, feeGrowthInside1X128.Sign())
+
+	// some outside
+	half := new(big.Int).Rsh(constants.Q128, 1)
+	some := &FeeGrowthOutside{FeeGrowthOutside0X128: half, FeeGrowthOutside1X128: half}
+	feeGrowthInside0X128, feeGrowthInside1X128 = GetFeeGrowthInside(some, zero, -1, 1, 0, constants.Q128, constants.Q128)
+	assert.Equal(t, half, feeGrowthInside0X128)
+	assert.Equal(t, half, feeGrowthInside1X128)
+
+	// the range is below the current tick, and fee growth wraps around
+	feeGrowthInside0X128, _ = GetFeeGrowthInside(zero, some, -1, 1, 2, constants.Zero, constants.Zero)
+	assert.Equal(t, half, feeGrowthInside0X128)
+}